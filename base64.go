@@ -1,6 +1,9 @@
 package jose
 
-import "encoding/base64"
+import (
+	"bytes"
+	"encoding/base64"
+)
 
 // Encoder is satisfied if the type can marshal itself into a valid
 // structure for a JWS.
@@ -9,13 +12,33 @@ type Encoder interface {
 	Base64() ([]byte, error)
 }
 
-// Base64Decode decodes a base64-encoded byte slice.
+// Base64Decode decodes an unpadded base64url-encoded byte slice. Per
+// https://tools.ietf.org/html/rfc7515#section-2 padding is rejected, so
+// each JWS has exactly one valid encoding.
 func Base64Decode(b []byte) ([]byte, error) {
 	buf := make([]byte, base64.RawURLEncoding.DecodedLen(len(b)))
 	n, err := base64.RawURLEncoding.Decode(buf, b)
 	return buf[:n], err
 }
 
+// Base64DecodeLenient is like Base64Decode, but also accepts padded
+// input, for data produced by consumers which add the padding. It
+// must not be used to decode JWSs.
+func Base64DecodeLenient(b []byte) ([]byte, error) {
+	dec, err := Base64Decode(b)
+	if err != nil && bytes.IndexByte(b, '=') >= 0 {
+		return Base64DecodePadded(b)
+	}
+	return dec, err
+}
+
+// Base64DecodePadded decodes a padded base64url-encoded byte slice.
+func Base64DecodePadded(b []byte) ([]byte, error) {
+	buf := make([]byte, base64.URLEncoding.DecodedLen(len(b)))
+	n, err := base64.URLEncoding.Decode(buf, b)
+	return buf[:n], err
+}
+
 // Base64Encode encodes a byte slice.
 func Base64Encode(b []byte) []byte {
 	buf := make([]byte, base64.RawURLEncoding.EncodedLen(len(b)))
@@ -23,6 +46,14 @@ func Base64Encode(b []byte) []byte {
 	return buf
 }
 
+// Base64EncodePadded encodes a byte slice as padded base64url, for
+// consumers which require the padding.
+func Base64EncodePadded(b []byte) []byte {
+	buf := make([]byte, base64.URLEncoding.EncodedLen(len(b)))
+	base64.URLEncoding.Encode(buf, b)
+	return buf
+}
+
 // EncodeEscape base64-encodes a byte slice but escapes it for JSON.
 // It'll return the format: `"base64"`
 func EncodeEscape(b []byte) []byte {
@@ -23,3 +23,48 @@ func TestBase64(t *testing.T) {
 		Error(t, raw, testDec)
 	}
 }
+
+func TestBase64DecodeLenient(t *testing.T) {
+	raw := []byte("Hello, playground")
+	for _, enc := range []string{"SGVsbG8sIHBsYXlncm91bmQ", "SGVsbG8sIHBsYXlncm91bmQ="} {
+		dec, err := Base64DecodeLenient([]byte(enc))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(dec, raw) {
+			Error(t, raw, dec)
+		}
+	}
+
+	if _, err := Base64Decode([]byte("SGVsbG8sIHBsYXlncm91bmQ=")); err == nil {
+		t.Error("Base64Decode should reject padded input")
+	}
+	if _, err := DecodeEscaped([]byte(`"SGVsbG8sIHBsYXlncm91bmQ="`)); err == nil {
+		t.Error("DecodeEscaped should reject padded input")
+	}
+}
+
+func TestBase64Padded(t *testing.T) {
+	encoded := []byte("SGVsbG8sIHBsYXlncm91bmQ=")
+	raw := []byte("Hello, playground")
+
+	testEnc := Base64EncodePadded(raw)
+	if !bytes.Equal(testEnc, encoded) {
+		Error(t, encoded, testEnc)
+	}
+
+	testDec, err := Base64DecodePadded(testEnc)
+	if err != nil {
+		t.Error(err)
+	}
+	if !bytes.Equal(testDec, raw) {
+		Error(t, raw, testDec)
+	}
+
+	if _, err := Base64DecodePadded([]byte("SGVsbG8sIHBsYXlncm91bmQ")); err == nil {
+		t.Error("unpadded input should not be accepted")
+	}
+	if _, err := Base64DecodeLenient([]byte("SGVsbG8sIHBsYXlncm91bmQ==")); err == nil {
+		t.Error("incorrectly padded input should not be accepted")
+	}
+}
@@ -0,0 +1,54 @@
+package jose
+
+import (
+	"errors"
+	"strconv"
+)
+
+// ErrInvalidCriticalHeader means the "crit" Protected Header parameter
+// isn't a non-empty list of strings.
+var ErrInvalidCriticalHeader = errors.New(`"crit" header parameter is invalid`)
+
+// ErrUnknownCriticalHeader is returned by ProcessCritical when the
+// "crit" Protected Header parameter lists a parameter that isn't
+// understood.
+type ErrUnknownCriticalHeader struct {
+	Parameter string // Name of the unknown parameter.
+}
+
+// Error implements the error interface.
+func (e ErrUnknownCriticalHeader) Error() string {
+	return "unknown critical header parameter " + strconv.Quote(e.Parameter)
+}
+
+// ProcessCritical checks the "crit" Protected Header parameter per
+// https://tools.ietf.org/html/rfc7515#section-4.1.11
+//
+// It returns ErrUnknownCriticalHeader for the first parameter listed
+// in "crit" that isn't one of understood, and ErrInvalidCriticalHeader
+// if "crit" is malformed. If p doesn't have a "crit" parameter, it
+// returns nil.
+func ProcessCritical(p Protected, understood []string) error {
+	if !p.Has("crit") {
+		return nil
+	}
+	crit, ok := p.Critical()
+	if !ok || len(crit) == 0 {
+		return ErrInvalidCriticalHeader
+	}
+	for _, param := range crit {
+		if !contains(understood, param) {
+			return ErrUnknownCriticalHeader{Parameter: param}
+		}
+	}
+	return nil
+}
+
+func contains(a []string, s string) bool {
+	for _, v := range a {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,25 @@
+package jose
+
+import "testing"
+
+func TestProcessCritical(t *testing.T) {
+	if err := ProcessCritical(Protected{"alg": "RS256"}, nil); err != nil {
+		t.Error(err)
+	}
+
+	p := Protected{"crit": []interface{}{"exp", "b64"}}
+	if err := ProcessCritical(p, []string{"b64", "exp"}); err != nil {
+		t.Error(err)
+	}
+	want := ErrUnknownCriticalHeader{Parameter: "exp"}
+	if err := ProcessCritical(p, []string{"b64"}); err != want {
+		Error(t, want, err)
+	}
+
+	for _, crit := range []interface{}{"exp", []interface{}{}, []interface{}{1}} {
+		p := Protected{"crit": crit}
+		if err := ProcessCritical(p, []string{"exp"}); err != ErrInvalidCriticalHeader {
+			Error(t, ErrInvalidCriticalHeader, err)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package crypto
 import (
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"encoding/asn1"
 	"encoding/json"
@@ -52,17 +53,28 @@ var (
 func (m *SigningMethodECDSA) Alg() string { return m.Name }
 
 // Verify implements the Verify method from SigningMethod.
-// For this verify method, key must be an *ecdsa.PublicKey.
+// For this verify method, key must be an *ecdsa.PublicKey. The signature
+// must be ASN.1 DER-encoded; see VerifyASN1.
 func (m *SigningMethodECDSA) Verify(raw []byte, signature Signature, key interface{}) error {
+	return m.VerifyASN1(raw, signature, key)
+}
+
+// VerifyASN1 verifies the ASN.1 DER-encoded ECDSA signature derSig of
+// raw, as produced by SignDER, ecdsa.SignASN1, and most hardware
+// signers. key must be an *ecdsa.PublicKey.
+func (m *SigningMethodECDSA) VerifyASN1(raw, derSig []byte, key interface{}) error {
 
 	ecdsaKey, ok := key.(*ecdsa.PublicKey)
 	if !ok {
 		return ErrInvalidKey
 	}
+	if err := m.checkCurve(ecdsaKey); err != nil {
+		return err
+	}
 
 	// Unmarshal asn1 ECPoint
 	var ecpoint ECPoint
-	if _, err := asn1.Unmarshal(signature, &ecpoint); err != nil {
+	if _, err := asn1.Unmarshal(derSig, &ecpoint); err != nil {
 		return err
 	}
 
@@ -74,24 +86,75 @@ func (m *SigningMethodECDSA) Verify(raw []byte, signature Signature, key interfa
 }
 
 // Sign implements the Sign method from SigningMethod.
-// For this signing method, key must be an *ecdsa.PrivateKey.
+// For this signing method, key must be an *ecdsa.PrivateKey. The
+// signature is ASN.1 DER-encoded; see SignDER.
 func (m *SigningMethodECDSA) Sign(data []byte, key interface{}) (Signature, error) {
+	sig, err := m.SignDER(data, key)
+	if err != nil {
+		return nil, err
+	}
+	return Signature(sig), nil
+}
+
+// SignDER signs data and returns the ASN.1 DER-encoded ECDSA signature.
+// key must be an *ecdsa.PrivateKey.
+func (m *SigningMethodECDSA) SignDER(data []byte, key interface{}) ([]byte, error) {
 
 	ecdsaKey, ok := key.(*ecdsa.PrivateKey)
 	if !ok {
 		return nil, ErrInvalidKey
 	}
+	if err := m.checkCurve(&ecdsaKey.PublicKey); err != nil {
+		return nil, err
+	}
 
 	r, s, err := ecdsa.Sign(rand.Reader, ecdsaKey, m.sum(data))
 	if err != nil {
 		return nil, err
 	}
+	return asn1.Marshal(ECPoint{R: r, S: s})
+}
 
-	signature, err := asn1.Marshal(ECPoint{R: r, S: s})
-	if err != nil {
-		return nil, err
+// Curve returns the elliptic.Curve used by m, per
+// https://tools.ietf.org/html/rfc7518#section-3.4
+// It returns nil if m's hash isn't SHA-256, SHA-384, or SHA-512.
+func (m *SigningMethodECDSA) Curve() elliptic.Curve {
+	switch m.Hash {
+	case crypto.SHA256:
+		return elliptic.P256()
+	case crypto.SHA384:
+		return elliptic.P384()
+	case crypto.SHA512:
+		return elliptic.P521()
+	}
+	return nil
+}
+
+// ValidateKey implements the SigningMethod interface. key must be an
+// *ecdsa.PrivateKey or *ecdsa.PublicKey on m's Curve. If it's on a
+// different curve, an ErrCurveMismatch is returned.
+func (m *SigningMethodECDSA) ValidateKey(key interface{}) error {
+	switch k := key.(type) {
+	case *ecdsa.PrivateKey:
+		return m.checkCurve(&k.PublicKey)
+	case *ecdsa.PublicKey:
+		return m.checkCurve(k)
+	}
+	return ErrInvalidKey
+}
+
+// checkCurve returns an ErrCurveMismatch if key isn't on m's Curve.
+func (m *SigningMethodECDSA) checkCurve(key *ecdsa.PublicKey) error {
+	want := m.Curve()
+	if want == nil || key.Curve == nil ||
+		key.Curve.Params().Name == want.Params().Name {
+		return nil
+	}
+	return ErrCurveMismatch{
+		Alg:  m.Alg(),
+		Want: want.Params().Name,
+		Got:  key.Curve.Params().Name,
 	}
-	return Signature(signature), nil
 }
 
 func (m *SigningMethodECDSA) sum(b []byte) []byte {
@@ -1,5 +1,15 @@
 package crypto
 
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/asn1"
+	"errors"
+	"testing"
+)
+
 // import (
 // 	"crypto/ecdsa"
 // 	"io/ioutil"
@@ -98,3 +108,111 @@ package crypto
 // 		}
 // 	}
 // }
+
+func TestECDSACurve(t *testing.T) {
+	for _, tt := range []struct {
+		m     *SigningMethodECDSA
+		curve elliptic.Curve
+	}{
+		{SigningMethodES256, elliptic.P256()},
+		{SigningMethodES384, elliptic.P384()},
+		{SigningMethodES512, elliptic.P521()},
+		{&SigningMethodECDSA{Name: "ES1", Hash: 0}, nil},
+	} {
+		if c := tt.m.Curve(); c != tt.curve {
+			t.Errorf("%s: wanted %v, got %v", tt.m.Alg(), tt.curve, c)
+		}
+	}
+}
+
+func TestECDSACurveMismatch(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SigningMethodES384.ValidateKey(key); err != nil {
+		t.Error(err)
+	}
+
+	want := ErrCurveMismatch{Alg: "ES256", Want: "P-256", Got: "P-384"}
+	for _, err := range []error{
+		SigningMethodES256.ValidateKey(key),
+		SigningMethodES256.ValidateKey(&key.PublicKey),
+		SigningMethodES256.Verify([]byte("data"), nil, &key.PublicKey),
+	} {
+		if err != want {
+			Error(t, want, err)
+		}
+		if !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("%v should match ErrInvalidKey", err)
+		}
+	}
+	if _, err := SigningMethodES256.Sign([]byte("data"), key); err != want {
+		Error(t, want, err)
+	}
+	if got := want.Error(); got != "ES256 requires a P-256 key but got P-384" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestECDSADER(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("data")
+	digest := sha256.Sum256(data)
+
+	// Signatures from SignDER can be verified by the standard library...
+	sig, err := SigningMethodES256.SignDER(data, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ecdsa.VerifyASN1(&key.PublicKey, digest[:], sig) {
+		t.Error("ecdsa.VerifyASN1 rejected SignDER's signature")
+	}
+
+	// ...and vice versa, as with a hardware signer.
+	sig, err = ecdsa.SignASN1(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := SigningMethodES256.VerifyASN1(data, sig, &key.PublicKey); err != nil {
+		t.Error(err)
+	}
+	if err := SigningMethodES256.VerifyASN1([]byte("other"), sig, &key.PublicKey); err != ErrECDSAVerification {
+		Error(t, ErrECDSAVerification, err)
+	}
+	if err := SigningMethodES256.VerifyASN1(data, sig, key); err != ErrInvalidKey {
+		Error(t, ErrInvalidKey, err)
+	}
+}
+
+// TestECDSAP521ShortR verifies P-521 signatures whose r value is shorter
+// than the curve's 66-byte size, i.e. has leading zero bytes.
+func TestECDSAP521ShortR(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("data")
+	for i := 0; i < 100; i++ {
+		sig, err := SigningMethodES512.Sign(data, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var p ECPoint
+		if _, err := asn1.Unmarshal(sig, &p); err != nil {
+			t.Fatal(err)
+		}
+		if len(p.R.Bytes()) >= 66 {
+			continue
+		}
+		if err := SigningMethodES512.Verify(data, sig, &key.PublicKey); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	t.Fatal("couldn't produce a signature with a short r")
+}
@@ -2,6 +2,8 @@ package crypto
 
 import (
 	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/x509"
 	"encoding/pem"
 	"errors"
@@ -14,13 +16,27 @@ var (
 )
 
 // ParseECPrivateKeyFromPEM will parse a PEM encoded EC Private
-// Key Structure.
+// Key Structure or PKCS8 private key.
 func ParseECPrivateKeyFromPEM(key []byte) (*ecdsa.PrivateKey, error) {
 	block, _ := pem.Decode(key)
 	if block == nil {
 		return nil, ErrKeyMustBePEMEncoded
 	}
-	return x509.ParseECPrivateKey(block.Bytes)
+
+	pkey, err := x509.ParseECPrivateKey(block.Bytes)
+	if err == nil {
+		return pkey, nil
+	}
+	parsedKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	pkey, ok := parsedKey.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, ErrNotECPrivateKey
+	}
+	return pkey, nil
 }
 
 // ParseECPublicKeyFromPEM will parse a PEM encoded PKCS1 or PKCS8 public key
@@ -46,3 +62,8 @@ func ParseECPublicKeyFromPEM(key []byte) (*ecdsa.PublicKey, error) {
 	}
 	return pkey, nil
 }
+
+// GenerateECDSAKey generates an ECDSA private key on the given curve.
+func GenerateECDSAKey(curve elliptic.Curve) (*ecdsa.PrivateKey, error) {
+	return ecdsa.GenerateKey(curve, rand.Reader)
+}
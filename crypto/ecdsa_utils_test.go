@@ -0,0 +1,50 @@
+package crypto
+
+import (
+	"crypto/elliptic"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+)
+
+func TestParseECPrivateKeyFromPEM(t *testing.T) {
+	priv, err := GenerateECDSAKey(elliptic.P256())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sec1, err := x509.MarshalECPrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blocks := []*pem.Block{
+		{Type: "EC PRIVATE KEY", Bytes: sec1},
+		{Type: "PRIVATE KEY", Bytes: pkcs8},
+	}
+	for _, block := range blocks {
+		key, err := ParseECPrivateKeyFromPEM(pem.EncodeToMemory(block))
+		if err != nil {
+			t.Fatalf("%s: %v", block.Type, err)
+		}
+		if key.D.Cmp(priv.D) != 0 {
+			t.Errorf("%s: parsed key doesn't match", block.Type)
+		}
+	}
+
+	rsaKey, err := GenerateRSAKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkcs8, err = x509.MarshalPKCS8PrivateKey(rsaKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8})
+	if _, err := ParseECPrivateKeyFromPEM(b); err != ErrNotECPrivateKey {
+		t.Errorf("wanted %v, got %v", ErrNotECPrivateKey, err)
+	}
+}
@@ -0,0 +1,94 @@
+package crypto
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"encoding/json"
+)
+
+// SigningMethodEd25519 implements the Ed25519 family of signing methods.
+// If Hash is non-zero, the input is pre-hashed with it, per the Ed25519ph
+// variant in https://tools.ietf.org/html/rfc8032#section-5.1
+type SigningMethodEd25519 struct {
+	Name string
+	Hash crypto.Hash
+	_    struct{}
+}
+
+// SigningMethodEd25519ph implements Ed25519ph, pre-hashing the input with
+// SHA-512.
+var SigningMethodEd25519ph = &SigningMethodEd25519{
+	Name: "Ed25519ph",
+	Hash: crypto.SHA512,
+}
+
+// Alg implements the SigningMethod interface.
+func (m *SigningMethodEd25519) Alg() string { return m.Name }
+
+// Verify implements the Verify method from SigningMethod.
+// For this verify method, key must be an ed25519.PublicKey.
+func (m *SigningMethodEd25519) Verify(raw []byte, sig Signature, key interface{}) error {
+	edKey, ok := key.(ed25519.PublicKey)
+	if !ok || len(edKey) != ed25519.PublicKeySize {
+		return ErrInvalidKey
+	}
+	if err := ed25519.VerifyWithOptions(edKey, m.sum(raw), sig, m.options()); err != nil {
+		return ErrSignatureInvalid
+	}
+	return nil
+}
+
+// Sign implements the Sign method from SigningMethod.
+// For this signing method, key must be an ed25519.PrivateKey.
+func (m *SigningMethodEd25519) Sign(data []byte, key interface{}) (Signature, error) {
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok || len(edKey) != ed25519.PrivateKeySize {
+		return nil, ErrInvalidKey
+	}
+	sig, err := edKey.Sign(nil, m.sum(data), m.options())
+	if err != nil {
+		return nil, err
+	}
+	return Signature(sig), nil
+}
+
+// ValidateKey implements the SigningMethod interface. key must be an
+// ed25519.PrivateKey or ed25519.PublicKey.
+func (m *SigningMethodEd25519) ValidateKey(key interface{}) error {
+	switch k := key.(type) {
+	case ed25519.PrivateKey:
+		if len(k) == ed25519.PrivateKeySize {
+			return nil
+		}
+	case ed25519.PublicKey:
+		if len(k) == ed25519.PublicKeySize {
+			return nil
+		}
+	}
+	return ErrInvalidKey
+}
+
+func (m *SigningMethodEd25519) options() *ed25519.Options {
+	return &ed25519.Options{Hash: m.Hash}
+}
+
+// sum returns b pre-hashed with m.Hash, or b itself if m.Hash is zero.
+func (m *SigningMethodEd25519) sum(b []byte) []byte {
+	if m.Hash == 0 {
+		return b
+	}
+	h := m.Hash.New()
+	h.Write(b)
+	return h.Sum(nil)
+}
+
+// Hasher implements the SigningMethod interface.
+func (m *SigningMethodEd25519) Hasher() crypto.Hash { return m.Hash }
+
+// MarshalJSON implements json.Marshaler.
+// See SigningMethodECDSA.MarshalJSON() for information.
+func (m *SigningMethodEd25519) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + m.Alg() + `"`), nil
+}
+
+var _ json.Marshaler = (*SigningMethodEd25519)(nil)
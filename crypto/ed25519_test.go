@@ -0,0 +1,52 @@
+package crypto
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/sha512"
+	"testing"
+)
+
+func TestEd25519ph(t *testing.T) {
+	pub, priv, err := GenerateEd25519Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("data")
+
+	sig, err := SigningMethodEd25519ph.Sign(data, priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := SigningMethodEd25519ph.Verify(data, sig, pub); err != nil {
+		t.Fatal(err)
+	}
+
+	// The signature must be a standard Ed25519ph signature of the
+	// SHA-512 digest.
+	digest := sha512.Sum512(data)
+	if err := ed25519.VerifyWithOptions(pub, digest[:], sig, &ed25519.Options{Hash: crypto.SHA512}); err != nil {
+		t.Error(err)
+	}
+	if ed25519.Verify(pub, data, sig) {
+		t.Error("Ed25519ph signature verified as pure Ed25519")
+	}
+
+	if err := SigningMethodEd25519ph.Verify([]byte("other"), sig, pub); err != ErrSignatureInvalid {
+		Error(t, ErrSignatureInvalid, err)
+	}
+	if err := SigningMethodEd25519ph.Verify(data, sig, priv); err != ErrInvalidKey {
+		Error(t, ErrInvalidKey, err)
+	}
+	if _, err := SigningMethodEd25519ph.Sign(data, pub); err != ErrInvalidKey {
+		Error(t, ErrInvalidKey, err)
+	}
+	for _, key := range []interface{}{pub, priv} {
+		if err := SigningMethodEd25519ph.ValidateKey(key); err != nil {
+			t.Errorf("%T: %v", key, err)
+		}
+	}
+	if err := SigningMethodEd25519ph.ValidateKey([]byte("key")); err != ErrInvalidKey {
+		Error(t, ErrInvalidKey, err)
+	}
+}
@@ -0,0 +1,64 @@
+package crypto
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+)
+
+// Ed25519 parsing errors.
+var (
+	ErrNotEd25519PublicKey  = errors.New("key is not a valid Ed25519 public key")
+	ErrNotEd25519PrivateKey = errors.New("key is not a valid Ed25519 private key")
+)
+
+// ParseEd25519PrivateKeyFromPEM will parse a PEM encoded PKCS8 private
+// key.
+func ParseEd25519PrivateKeyFromPEM(key []byte) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode(key)
+	if block == nil {
+		return nil, ErrKeyMustBePEMEncoded
+	}
+
+	parsedKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	pkey, ok := parsedKey.(ed25519.PrivateKey)
+	if !ok {
+		return nil, ErrNotEd25519PrivateKey
+	}
+	return pkey, nil
+}
+
+// ParseEd25519PublicKeyFromPEM will parse a PEM encoded PKIX public key
+// or certificate.
+func ParseEd25519PublicKeyFromPEM(key []byte) (ed25519.PublicKey, error) {
+	block, _ := pem.Decode(key)
+	if block == nil {
+		return nil, ErrKeyMustBePEMEncoded
+	}
+
+	parsedKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		parsedKey = cert.PublicKey
+	}
+
+	pkey, ok := parsedKey.(ed25519.PublicKey)
+	if !ok {
+		return nil, ErrNotEd25519PublicKey
+	}
+	return pkey, nil
+}
+
+// GenerateEd25519Key generates an Ed25519 key pair.
+func GenerateEd25519Key() (ed25519.PublicKey, ed25519.PrivateKey, error) {
+	return ed25519.GenerateKey(rand.Reader)
+}
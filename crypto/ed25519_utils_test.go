@@ -0,0 +1,65 @@
+package crypto
+
+import (
+	"crypto/elliptic"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+)
+
+func TestParseEd25519KeysFromPEM(t *testing.T) {
+	pub, priv, err := GenerateEd25519Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := ParseEd25519PrivateKeyFromPEM(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !priv.Equal(key) {
+		t.Error("parsed private key doesn't match")
+	}
+
+	pkix, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkey, err := ParseEd25519PublicKeyFromPEM(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pkix}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pub.Equal(pkey) {
+		t.Error("parsed public key doesn't match")
+	}
+
+	if _, err := ParseEd25519PublicKeyFromPEM([]byte("not PEM")); err != ErrKeyMustBePEMEncoded {
+		Error(t, ErrKeyMustBePEMEncoded, err)
+	}
+	if _, err := ParseEd25519PrivateKeyFromPEM([]byte("not PEM")); err != ErrKeyMustBePEMEncoded {
+		Error(t, ErrKeyMustBePEMEncoded, err)
+	}
+
+	ecKey, err := GenerateECDSAKey(elliptic.P256())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecPKCS8, err := x509.MarshalPKCS8PrivateKey(ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseEd25519PrivateKeyFromPEM(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: ecPKCS8})); err != ErrNotEd25519PrivateKey {
+		Error(t, ErrNotEd25519PrivateKey, err)
+	}
+	ecPKIX, err := x509.MarshalPKIXPublicKey(&ecKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseEd25519PublicKeyFromPEM(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: ecPKIX})); err != ErrNotEd25519PublicKey {
+		Error(t, ErrNotEd25519PublicKey, err)
+	}
+}
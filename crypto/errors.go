@@ -6,4 +6,28 @@ var (
 	// ErrInvalidKey means the key argument passed to SigningMethod.Verify
 	// was not the correct type.
 	ErrInvalidKey = errors.New("key is invalid")
+
+	// ErrKeyTooSmall means the RSA or HMAC key passed to
+	// SigningMethod.Sign or SigningMethod.Verify is smaller than the
+	// SigningMethod's minimum.
+	ErrKeyTooSmall = errors.New("key is too small")
 )
+
+// ErrCurveMismatch means the ECDSA key passed to a SigningMethodECDSA
+// isn't on the curve required by its algorithm. errors.Is reports that
+// it matches ErrInvalidKey.
+type ErrCurveMismatch struct {
+	Alg  string // Algorithm of the SigningMethodECDSA, e.g. "ES256".
+	Want string // Name of the required curve, e.g. "P-256".
+	Got  string // Name of the key's curve.
+}
+
+// Error implements the error interface.
+func (e ErrCurveMismatch) Error() string {
+	return e.Alg + " requires a " + e.Want + " key but got " + e.Got
+}
+
+// Is reports whether target is ErrInvalidKey.
+func (e ErrCurveMismatch) Is(target error) bool {
+	return target == ErrInvalidKey
+}
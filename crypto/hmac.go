@@ -3,6 +3,7 @@ package crypto
 import (
 	"crypto"
 	"crypto/hmac"
+	"crypto/rand"
 	"encoding/json"
 	"errors"
 )
@@ -11,27 +12,37 @@ import (
 type SigningMethodHMAC struct {
 	Name string
 	Hash crypto.Hash
-	_    struct{}
+
+	// MinKeyLen is the minimum length of the key, in bytes, accepted
+	// by Sign and Verify. If zero, the size of Hash's output is used,
+	// per https://tools.ietf.org/html/rfc7518#section-3.2
+	// A negative value disables the check.
+	MinKeyLen int
+
+	_ struct{}
 }
 
 // Specific instances of HMAC-SHA SigningMethods.
 var (
 	// SigningMethodHS256 implements HS256.
 	SigningMethodHS256 = &SigningMethodHMAC{
-		Name: "HS256",
-		Hash: crypto.SHA256,
+		Name:      "HS256",
+		Hash:      crypto.SHA256,
+		MinKeyLen: 32,
 	}
 
 	// SigningMethodHS384 implements HS384.
 	SigningMethodHS384 = &SigningMethodHMAC{
-		Name: "HS384",
-		Hash: crypto.SHA384,
+		Name:      "HS384",
+		Hash:      crypto.SHA384,
+		MinKeyLen: 48,
 	}
 
 	// SigningMethodHS512 implements HS512.
 	SigningMethodHS512 = &SigningMethodHMAC{
-		Name: "HS512",
-		Hash: crypto.SHA512,
+		Name:      "HS512",
+		Hash:      crypto.SHA512,
+		MinKeyLen: 64,
 	}
 
 	// ErrSignatureInvalid is returned when the provided signature is found
@@ -49,6 +60,9 @@ func (m *SigningMethodHMAC) Verify(raw []byte, signature Signature, key interfac
 	if !ok {
 		return ErrInvalidKey
 	}
+	if err := m.checkKeyLen(keyBytes); err != nil {
+		return err
+	}
 	hasher := hmac.New(m.Hash.New, keyBytes)
 	hasher.Write(raw)
 	if hmac.Equal(signature, hasher.Sum(nil)) {
@@ -64,11 +78,54 @@ func (m *SigningMethodHMAC) Sign(data []byte, key interface{}) (Signature, error
 	if !ok {
 		return nil, ErrInvalidKey
 	}
+	if err := m.checkKeyLen(keyBytes); err != nil {
+		return nil, err
+	}
 	hasher := hmac.New(m.Hash.New, keyBytes)
 	hasher.Write(data)
 	return Signature(hasher.Sum(nil)), nil
 }
 
+// ValidateKey implements the SigningMethod interface. key must be a
+// []byte of at least m.MinKeyLen bytes.
+func (m *SigningMethodHMAC) ValidateKey(key interface{}) error {
+	keyBytes, ok := key.([]byte)
+	if !ok {
+		return ErrInvalidKey
+	}
+	if err := m.checkKeyLen(keyBytes); err != nil {
+		return err
+	}
+	return nil
+}
+
+// checkKeyLen returns ErrKeyTooSmall if key is shorter than
+// m.MinKeyLen.
+func (m *SigningMethodHMAC) checkKeyLen(key []byte) error {
+	min := m.MinKeyLen
+	if min == 0 && m.Hash.Available() {
+		min = m.Hash.Size()
+	}
+	if min > 0 && len(key) < min {
+		return ErrKeyTooSmall
+	}
+	return nil
+}
+
+// NewHMACKey returns a random key of the given size in bits, suitable
+// for the HMAC-SHA SigningMethods. It returns ErrKeyTooSmall if bits is
+// less than 128.
+func NewHMACKey(bits int) ([]byte, error) {
+	if bits < 128 {
+		return nil, ErrKeyTooSmall
+	}
+	key := make([]byte, bits/8)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
 // Hasher implements the SigningMethod interface.
 func (m *SigningMethodHMAC) Hasher() crypto.Hash { return m.Hash }
 
@@ -1,5 +1,10 @@
 package crypto
 
+import (
+	"crypto"
+	"testing"
+)
+
 // import (
 // 	"io/ioutil"
 // 	"strings"
@@ -90,3 +95,52 @@ package crypto
 // // func BenchmarkHS512Signing(b *testing.B) {
 // // 	benchmarkSigning(b, jwt.SigningMethodHS512, hmacTestKey)
 // // }
+
+func TestHMACMinKeyLen(t *testing.T) {
+	short := make([]byte, 31)
+	if _, err := SigningMethodHS256.Sign([]byte("data"), short); err != ErrKeyTooSmall {
+		t.Errorf("wanted %v, got %v", ErrKeyTooSmall, err)
+	}
+	if err := SigningMethodHS256.Verify([]byte("data"), nil, short); err != ErrKeyTooSmall {
+		t.Errorf("wanted %v, got %v", ErrKeyTooSmall, err)
+	}
+
+	key := make([]byte, 32)
+	sig, err := SigningMethodHS256.Sign([]byte("data"), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := SigningMethodHS256.Verify([]byte("data"), sig, key); err != nil {
+		t.Error(err)
+	}
+	if _, err := SigningMethodHS512.Sign([]byte("data"), key); err != ErrKeyTooSmall {
+		t.Errorf("wanted %v, got %v", ErrKeyTooSmall, err)
+	}
+
+	// The zero value uses the size of the hash's output.
+	m := &SigningMethodHMAC{Name: "HS256", Hash: crypto.SHA256}
+	if _, err := m.Sign([]byte("data"), short); err != ErrKeyTooSmall {
+		t.Errorf("wanted %v, got %v", ErrKeyTooSmall, err)
+	}
+	m.MinKeyLen = -1
+	if _, err := m.Sign([]byte("data"), short); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestNewHMACKey(t *testing.T) {
+	if _, err := NewHMACKey(64); err != ErrKeyTooSmall {
+		t.Errorf("wanted %v, got %v", ErrKeyTooSmall, err)
+	}
+
+	key, err := NewHMACKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(key) != 32 {
+		t.Errorf("wanted a 32-byte key, got %d bytes", len(key))
+	}
+	if _, err := SigningMethodHS256.Sign([]byte("data"), key); err != nil {
+		t.Error(err)
+	}
+}
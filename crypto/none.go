@@ -3,34 +3,10 @@ package crypto
 import (
 	"crypto"
 	"encoding/json"
-	"hash"
-	"io"
 )
 
-func init() {
-	crypto.RegisterHash(crypto.Hash(0), h)
-}
-
-// h is passed to crypto.RegisterHash.
-func h() hash.Hash {
-	return &f{Writer: nil}
-}
-
-type f struct{ io.Writer }
-
-// Sum helps implement the hash.Hash interface.
-func (_ *f) Sum(b []byte) []byte { return nil }
-
-// Reset helps implement the hash.Hash interface.
-func (_ *f) Reset() {}
-
-// Size helps implement the hash.Hash interface.
-func (_ *f) Size() int { return -1 }
-
-// BlockSize helps implement the hash.Hash interface.
-func (_ *f) BlockSize() int { return -1 }
-
-// Unsecured is the default "none" algorithm.
+// Unsecured is the default "none" algorithm. It doesn't hash, so its
+// Hasher is crypto.Hash(0).
 var Unsecured = &SigningMethodNone{
 	Name: "none",
 	Hash: crypto.Hash(0),
@@ -53,6 +29,11 @@ func (_ *SigningMethodNone) Sign(_ []byte, _ interface{}) (Signature, error) {
 	return nil, nil
 }
 
+// ValidateKey helps implement the SigningMethod interface.
+func (_ *SigningMethodNone) ValidateKey(_ interface{}) error {
+	return nil
+}
+
 // Alg helps implement the SigningMethod interface.
 func (m *SigningMethodNone) Alg() string {
 	return m.Name
@@ -11,27 +11,40 @@ import (
 type SigningMethodRSA struct {
 	Name string
 	Hash crypto.Hash
-	_    struct{}
+
+	// MinKeyBits is the minimum size of the RSA modulus, in bits,
+	// accepted by Sign and Verify. If zero, DefaultMinRSAKeyBits is
+	// used. A negative value disables the check.
+	MinKeyBits int
+
+	_ struct{}
 }
 
+// DefaultMinRSAKeyBits is the MinKeyBits of the predefined RSA and
+// RSA-PSS SigningMethods, per NIST SP 800-57.
+const DefaultMinRSAKeyBits = 2048
+
 // Specific instances of RSA SigningMethods.
 var (
 	// SigningMethodRS256 implements RS256.
 	SigningMethodRS256 = &SigningMethodRSA{
-		Name: "RS256",
-		Hash: crypto.SHA256,
+		Name:       "RS256",
+		Hash:       crypto.SHA256,
+		MinKeyBits: DefaultMinRSAKeyBits,
 	}
 
 	// SigningMethodRS384 implements RS384.
 	SigningMethodRS384 = &SigningMethodRSA{
-		Name: "RS384",
-		Hash: crypto.SHA384,
+		Name:       "RS384",
+		Hash:       crypto.SHA384,
+		MinKeyBits: DefaultMinRSAKeyBits,
 	}
 
 	// SigningMethodRS512 implements RS512.
 	SigningMethodRS512 = &SigningMethodRSA{
-		Name: "RS512",
-		Hash: crypto.SHA512,
+		Name:       "RS512",
+		Hash:       crypto.SHA512,
+		MinKeyBits: DefaultMinRSAKeyBits,
 	}
 )
 
@@ -45,6 +58,9 @@ func (m *SigningMethodRSA) Verify(raw []byte, sig Signature, key interface{}) er
 	if !ok {
 		return ErrInvalidKey
 	}
+	if err := m.checkKeySize(rsaKey); err != nil {
+		return err
+	}
 	return rsa.VerifyPKCS1v15(rsaKey, m.Hash, m.sum(raw), sig)
 }
 
@@ -55,6 +71,9 @@ func (m *SigningMethodRSA) Sign(data []byte, key interface{}) (Signature, error)
 	if !ok {
 		return nil, ErrInvalidKey
 	}
+	if err := m.checkKeySize(&rsaKey.PublicKey); err != nil {
+		return nil, err
+	}
 	sigBytes, err := rsa.SignPKCS1v15(rand.Reader, rsaKey, m.Hash, m.sum(data))
 	if err != nil {
 		return nil, err
@@ -62,6 +81,32 @@ func (m *SigningMethodRSA) Sign(data []byte, key interface{}) (Signature, error)
 	return Signature(sigBytes), nil
 }
 
+// ValidateKey implements the SigningMethod interface. key must be an
+// *rsa.PrivateKey or *rsa.PublicKey whose modulus is at least
+// m.MinKeyBits long.
+func (m *SigningMethodRSA) ValidateKey(key interface{}) error {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return m.checkKeySize(&k.PublicKey)
+	case *rsa.PublicKey:
+		return m.checkKeySize(k)
+	}
+	return ErrInvalidKey
+}
+
+// checkKeySize returns ErrKeyTooSmall if key's modulus is smaller than
+// m.MinKeyBits.
+func (m *SigningMethodRSA) checkKeySize(key *rsa.PublicKey) error {
+	min := m.MinKeyBits
+	if min == 0 {
+		min = DefaultMinRSAKeyBits
+	}
+	if min > 0 && key.N.BitLen() < min {
+		return ErrKeyTooSmall
+	}
+	return nil
+}
+
 func (m *SigningMethodRSA) sum(b []byte) []byte {
 	h := m.Hash.New()
 	h.Write(b)
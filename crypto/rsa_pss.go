@@ -12,6 +12,11 @@ import (
 // SigningMethodRSAPSS implements the RSAPSS family of SigningMethods.
 type SigningMethodRSAPSS struct {
 	*SigningMethodRSA
+
+	// Options are passed to rsa.SignPSS and rsa.VerifyPSS. The
+	// predefined SigningMethods use rsa.PSSSaltLengthAuto; a
+	// SigningMethodRSAPSS with, e.g., rsa.PSSSaltLengthEqualsHash can be
+	// registered for peers which require a fixed salt length.
 	Options *rsa.PSSOptions
 }
 
@@ -20,8 +25,9 @@ var (
 	// SigningMethodPS256 implements PS256.
 	SigningMethodPS256 = &SigningMethodRSAPSS{
 		&SigningMethodRSA{
-			Name: "PS256",
-			Hash: crypto.SHA256,
+			Name:       "PS256",
+			Hash:       crypto.SHA256,
+			MinKeyBits: DefaultMinRSAKeyBits,
 		},
 		&rsa.PSSOptions{
 			SaltLength: rsa.PSSSaltLengthAuto,
@@ -32,8 +38,9 @@ var (
 	// SigningMethodPS384 implements PS384.
 	SigningMethodPS384 = &SigningMethodRSAPSS{
 		&SigningMethodRSA{
-			Name: "PS384",
-			Hash: crypto.SHA384,
+			Name:       "PS384",
+			Hash:       crypto.SHA384,
+			MinKeyBits: DefaultMinRSAKeyBits,
 		},
 		&rsa.PSSOptions{
 			SaltLength: rsa.PSSSaltLengthAuto,
@@ -44,8 +51,9 @@ var (
 	// SigningMethodPS512 implements PS512.
 	SigningMethodPS512 = &SigningMethodRSAPSS{
 		&SigningMethodRSA{
-			Name: "PS512",
-			Hash: crypto.SHA512,
+			Name:       "PS512",
+			Hash:       crypto.SHA512,
+			MinKeyBits: DefaultMinRSAKeyBits,
 		},
 		&rsa.PSSOptions{
 			SaltLength: rsa.PSSSaltLengthAuto,
@@ -61,6 +69,9 @@ func (m *SigningMethodRSAPSS) Verify(raw []byte, signature Signature, key interf
 	if !ok {
 		return ErrInvalidKey
 	}
+	if err := m.checkKeySize(rsaKey); err != nil {
+		return err
+	}
 	return rsa.VerifyPSS(rsaKey, m.Hash, m.sum(raw), signature, m.Options)
 }
 
@@ -71,6 +82,9 @@ func (m *SigningMethodRSAPSS) Sign(raw []byte, key interface{}) (Signature, erro
 	if !ok {
 		return nil, ErrInvalidKey
 	}
+	if err := m.checkKeySize(&rsaKey.PublicKey); err != nil {
+		return nil, err
+	}
 	sigBytes, err := rsa.SignPSS(rand.Reader, rsaKey, m.Hash, m.sum(raw), m.Options)
 	if err != nil {
 		return nil, err
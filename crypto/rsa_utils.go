@@ -1,6 +1,7 @@
 package crypto
 
 import (
+	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
@@ -40,7 +41,52 @@ func ParseRSAPrivateKeyFromPEM(key []byte) (*rsa.PrivateKey, error) {
 	return pkey, nil
 }
 
-// ParseRSAPublicKeyFromPEM parses PEM encoded PKCS1 or PKCS8 public key.
+// ParseRSAPrivateKeyFromPEMWithPassword parses a PEM encoded PKCS1 or
+// PKCS8 private key that's been encrypted with the given password per
+// RFC 1423. If the PEM block isn't encrypted, it's parsed with
+// ParseRSAPrivateKeyFromPEM and the password is ignored.
+//
+// RFC 1423 encryption is insecure by design: it's unauthenticated, so
+// a wrong password can't always be detected, and it's vulnerable to
+// padding oracle attacks. It's only supported for legacy keys, and
+// relies on x509.DecryptPEMBlock, which is deprecated for that reason.
+// Encrypted PKCS8 ("ENCRYPTED PRIVATE KEY") blocks aren't supported.
+func ParseRSAPrivateKeyFromPEMWithPassword(key, password []byte) (*rsa.PrivateKey, error) {
+	var err error
+
+	// Parse PEM block
+	var block *pem.Block
+	if block, _ = pem.Decode(key); block == nil {
+		return nil, ErrKeyMustBePEMEncoded
+	}
+
+	if !x509.IsEncryptedPEMBlock(block) {
+		return ParseRSAPrivateKeyFromPEM(key)
+	}
+
+	var der []byte
+	if der, err = x509.DecryptPEMBlock(block, password); err != nil {
+		return nil, err
+	}
+
+	var parsedKey interface{}
+	if parsedKey, err = x509.ParsePKCS1PrivateKey(der); err != nil {
+		if parsedKey, err = x509.ParsePKCS8PrivateKey(der); err != nil {
+			return nil, err
+		}
+	}
+
+	var pkey *rsa.PrivateKey
+	var ok bool
+	if pkey, ok = parsedKey.(*rsa.PrivateKey); !ok {
+		return nil, ErrNotRSAPrivateKey
+	}
+
+	return pkey, nil
+}
+
+// ParseRSAPublicKeyFromPEM parses PEM encoded PKIX or PKCS1 public key,
+// or the public key of a PEM encoded certificate.
 func ParseRSAPublicKeyFromPEM(key []byte) (*rsa.PublicKey, error) {
 	var err error
 
@@ -53,7 +99,9 @@ func ParseRSAPublicKeyFromPEM(key []byte) (*rsa.PublicKey, error) {
 	// Parse the key
 	var parsedKey interface{}
 	if parsedKey, err = x509.ParsePKIXPublicKey(block.Bytes); err != nil {
-		if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+		if pkcs1, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+			parsedKey = pkcs1
+		} else if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
 			parsedKey = cert.PublicKey
 		} else {
 			return nil, err
@@ -68,3 +116,8 @@ func ParseRSAPublicKeyFromPEM(key []byte) (*rsa.PublicKey, error) {
 
 	return pkey, nil
 }
+
+// GenerateRSAKey generates an RSA private key of the given bit size.
+func GenerateRSAKey(bits int) (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, bits)
+}
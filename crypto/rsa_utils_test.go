@@ -0,0 +1,162 @@
+package crypto
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+)
+
+func TestParseRSAPublicKeyFromPEM(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pkix, err := x509.MarshalPKIXPublicKey(&priv.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blocks := []*pem.Block{
+		{Type: "PUBLIC KEY", Bytes: pkix},
+		{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(&priv.PublicKey)},
+	}
+	for _, block := range blocks {
+		pub, err := ParseRSAPublicKeyFromPEM(pem.EncodeToMemory(block))
+		if err != nil {
+			t.Fatalf("%s: %v", block.Type, err)
+		}
+		if pub.N.Cmp(priv.N) != 0 || pub.E != priv.E {
+			t.Errorf("%s: parsed key doesn't match", block.Type)
+		}
+	}
+
+	if _, err := ParseRSAPublicKeyFromPEM([]byte("not PEM")); err != ErrKeyMustBePEMEncoded {
+		t.Errorf("wanted %v, got %v", ErrKeyMustBePEMEncoded, err)
+	}
+}
+
+func TestParseRSAPrivateKeyFromPEMWithPassword(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der := x509.MarshalPKCS1PrivateKey(priv)
+	block, err := x509.EncryptPEMBlock(rand.Reader, "RSA PRIVATE KEY", der, []byte("hunter2"), x509.PEMCipherAES256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := pem.EncodeToMemory(block)
+
+	key, err := ParseRSAPrivateKeyFromPEMWithPassword(b, []byte("hunter2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key.N.Cmp(priv.N) != 0 {
+		t.Error("parsed key doesn't match")
+	}
+
+	if _, err := ParseRSAPrivateKeyFromPEMWithPassword(b, []byte("wrong")); err == nil {
+		t.Error("wanted an error for the wrong password")
+	}
+
+	b = pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: der})
+	if _, err := ParseRSAPrivateKeyFromPEM(b); err != nil {
+		t.Error(err)
+	}
+
+	// Unencrypted keys don't need a password.
+	key, err = ParseRSAPrivateKeyFromPEMWithPassword(b, []byte("hunter2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key.N.Cmp(priv.N) != 0 {
+		t.Error("parsed key doesn't match")
+	}
+}
+
+func TestGenerateKeys(t *testing.T) {
+	rsaKey, err := GenerateRSAKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := rsaKey.N.BitLen(); n != 2048 {
+		t.Errorf("wanted a 2048-bit key, got %d bits", n)
+	}
+
+	pub, priv, err := GenerateEd25519Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pub.Equal(priv.Public()) {
+		t.Error("Ed25519 public key doesn't match private key")
+	}
+}
+
+func TestRSAMinKeyBits(t *testing.T) {
+	key, err := GenerateRSAKey(1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, m := range []SigningMethod{SigningMethodRS256, SigningMethodPS256} {
+		if _, err := m.Sign([]byte("data"), key); err != ErrKeyTooSmall {
+			t.Errorf("%s: wanted %v, got %v", m.Alg(), ErrKeyTooSmall, err)
+		}
+		if err := m.Verify([]byte("data"), nil, &key.PublicKey); err != ErrKeyTooSmall {
+			t.Errorf("%s: wanted %v, got %v", m.Alg(), ErrKeyTooSmall, err)
+		}
+	}
+
+	legacy := &SigningMethodRSA{
+		Name:       "RS256",
+		Hash:       crypto.SHA256,
+		MinKeyBits: 1024,
+	}
+	sig, err := legacy.Sign([]byte("data"), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := legacy.Verify([]byte("data"), sig, &key.PublicKey); err != nil {
+		t.Error(err)
+	}
+
+	// The zero value uses DefaultMinRSAKeyBits.
+	legacy.MinKeyBits = 0
+	if _, err := legacy.Sign([]byte("data"), key); err != ErrKeyTooSmall {
+		t.Errorf("wanted %v, got %v", ErrKeyTooSmall, err)
+	}
+
+	legacy.MinKeyBits = -1
+	if err := legacy.Verify([]byte("data"), sig, &key.PublicKey); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRSAPSSSaltLength(t *testing.T) {
+	key, err := GenerateRSAKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := &SigningMethodRSAPSS{
+		SigningMethodPS256.SigningMethodRSA,
+		&rsa.PSSOptions{
+			SaltLength: rsa.PSSSaltLengthEqualsHash,
+			Hash:       crypto.SHA256,
+		},
+	}
+	sig, err := m.Sign([]byte("data"), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Verify([]byte("data"), sig, &key.PublicKey); err != nil {
+		t.Error(err)
+	}
+	if err := SigningMethodPS256.Verify([]byte("data"), sig, &key.PublicKey); err != nil {
+		t.Error(err)
+	}
+}
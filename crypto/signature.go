@@ -1,6 +1,8 @@
 package crypto
 
 import (
+	"crypto/subtle"
+	"encoding"
 	"encoding/json"
 
 	"github.com/SermoDigital/jose"
@@ -19,6 +21,28 @@ func (s Signature) Base64() ([]byte, error) {
 	return jose.Base64Encode(s), nil
 }
 
+// MarshalText implements encoding.TextMarshaler for Signature. The
+// signature is encoded as unpadded base64url.
+func (s Signature) MarshalText() ([]byte, error) {
+	return jose.Base64Encode(s), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for Signature. It
+// accepts both padded and unpadded base64url.
+func (s *Signature) UnmarshalText(b []byte) error {
+	dec, err := jose.Base64DecodeLenient(b)
+	if err != nil {
+		return err
+	}
+	*s = Signature(dec)
+	return nil
+}
+
+// Equal reports whether s and other are equal in constant time.
+func (s Signature) Equal(other Signature) bool {
+	return subtle.ConstantTimeCompare(s, other) == 1
+}
+
 // UnmarshalJSON implements json.Unmarshaler for signature.
 func (s *Signature) UnmarshalJSON(b []byte) error {
 	dec, err := jose.DecodeEscaped(b)
@@ -33,4 +57,7 @@ var (
 	_ json.Marshaler   = (Signature)(nil)
 	_ json.Unmarshaler = (*Signature)(nil)
 	_ jose.Encoder     = (Signature)(nil)
+
+	_ encoding.TextMarshaler   = (Signature)(nil)
+	_ encoding.TextUnmarshaler = (*Signature)(nil)
 )
@@ -23,3 +23,35 @@ func TestMarshalSignature(t *testing.T) {
 		Error(t, s, ss)
 	}
 }
+
+func TestSignatureEqual(t *testing.T) {
+	s := Signature("Test string!")
+	if !s.Equal(Signature("Test string!")) {
+		t.Error("equal signatures should be Equal")
+	}
+	if s.Equal(Signature("Test string?")) || s.Equal(Signature("Test")) || s.Equal(nil) {
+		t.Error("different signatures should not be Equal")
+	}
+}
+
+func TestSignatureText(t *testing.T) {
+	s := Signature("Test string!?")
+
+	b, err := s.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.ContainsAny(b, "=+/") {
+		t.Errorf("wanted unpadded base64url, got %q", b)
+	}
+
+	for _, enc := range [][]byte{b, append(b, "=="...)} {
+		var ss Signature
+		if err := ss.UnmarshalText(enc); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(ss, s) {
+			Error(t, s, ss)
+		}
+	}
+}
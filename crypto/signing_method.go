@@ -17,8 +17,14 @@ type SigningMethod interface {
 	// that occurred during the signing.
 	Sign(raw []byte, key interface{}) (Signature, error)
 
-	// Used to cause quick panics when a crypto.SigningMethod whose form of hashing
+	// Used to cause quick errors when a crypto.SigningMethod whose form of hashing
 	// isn't linked in the binary when you register a crypto.SigningMethod.
-	// To spoof this, see "crypto.SigningMethodNone".
+	// Methods which don't hash, like "crypto.SigningMethodNone", return
+	// crypto.Hash(0), which skips the check.
 	Hasher() crypto.Hash
+
+	// ValidateKey returns an error if key can't be used with the
+	// SigningMethod, e.g. because it's the wrong type or too small.
+	// It accepts both the keys used by Sign and those used by Verify.
+	ValidateKey(key interface{}) error
 }
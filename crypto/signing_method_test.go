@@ -0,0 +1,53 @@
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+)
+
+func TestValidateKey(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	smallRSAKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		m   SigningMethod
+		key interface{}
+		err error
+	}{
+		{SigningMethodHS256, make([]byte, 32), nil},
+		{SigningMethodHS256, make([]byte, 31), ErrKeyTooSmall},
+		{SigningMethodHS256, "secret", ErrInvalidKey},
+
+		{SigningMethodRS256, rsaKey, nil},
+		{SigningMethodRS256, &rsaKey.PublicKey, nil},
+		{SigningMethodRS256, smallRSAKey, ErrKeyTooSmall},
+		{SigningMethodRS256, ecKey, ErrInvalidKey},
+
+		{SigningMethodPS256, rsaKey, nil},
+		{SigningMethodPS256, &smallRSAKey.PublicKey, ErrKeyTooSmall},
+		{SigningMethodPS256, make([]byte, 32), ErrInvalidKey},
+
+		{SigningMethodES256, ecKey, nil},
+		{SigningMethodES256, &ecKey.PublicKey, nil},
+		{SigningMethodES256, rsaKey, ErrInvalidKey},
+
+		{Unsecured, nil, nil},
+	} {
+		if err := tt.m.ValidateKey(tt.key); err != tt.err {
+			t.Errorf("%s with %T: wanted %v, got %v", tt.m.Alg(), tt.key, tt.err, err)
+		}
+	}
+}
@@ -1,6 +1,14 @@
 package jose
 
-import "encoding/json"
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// ErrDuplicateHeaderParameter means a JOSE Header contains the same
+// parameter more than once.
+var ErrDuplicateHeaderParameter = errors.New("duplicate parameters in the JOSE Header")
 
 // Header implements a JOSE Header with the addition of some helper
 // methods, similar to net/url.Values.
@@ -30,6 +38,56 @@ func (h Header) Has(key string) bool {
 	return ok
 }
 
+// Type returns the "typ" parameter of the Header.
+func (h Header) Type() (string, bool) {
+	return getString(h, "typ")
+}
+
+// SetType sets the "typ" parameter of the Header.
+func (h Header) SetType(typ string) {
+	h.Set("typ", typ)
+}
+
+// ContentType returns the "cty" parameter of the Header.
+func (h Header) ContentType() (string, bool) {
+	return getString(h, "cty")
+}
+
+// SetContentType sets the "cty" parameter of the Header.
+func (h Header) SetContentType(cty string) {
+	h.Set("cty", cty)
+}
+
+// KeyID returns the "kid" parameter of the Header.
+func (h Header) KeyID() (string, bool) {
+	return getString(h, "kid")
+}
+
+// SetKeyID sets the "kid" parameter of the Header.
+func (h Header) SetKeyID(kid string) {
+	h.Set("kid", kid)
+}
+
+// Algorithm returns the "alg" parameter of the Header.
+func (h Header) Algorithm() (string, bool) {
+	return getString(h, "alg")
+}
+
+// Critical returns the "crit" parameter of the Header.
+func (h Header) Critical() ([]string, bool) {
+	return getStrings(h, "crit")
+}
+
+// Clone returns a deep copy of the Header. Maps and slices produced
+// by encoding/json, as well as []string values, are copied
+// recursively; any other values are copied as-is.
+func (h Header) Clone() Header {
+	if h == nil {
+		return nil
+	}
+	return Header(cloneMap(h))
+}
+
 // MarshalJSON implements json.Marshaler for Header.
 func (h Header) MarshalJSON() ([]byte, error) {
 	if len(h) == 0 {
@@ -59,6 +117,54 @@ func (h *Header) UnmarshalJSON(b []byte) error {
 	return json.Unmarshal(b, (*map[string]interface{})(h))
 }
 
+// UnmarshalJSONStrict is like UnmarshalJSON, but returns
+// ErrDuplicateHeaderParameter if a parameter name appears more than
+// once, per https://tools.ietf.org/html/rfc7515#section-4
+// UnmarshalJSON instead keeps the last value.
+func (h *Header) UnmarshalJSONStrict(b []byte) error {
+	if b == nil {
+		return nil
+	}
+	b, err := DecodeEscaped(b)
+	if err != nil {
+		return err
+	}
+	if err := checkDuplicateKeys(b); err != nil {
+		return err
+	}
+	return json.Unmarshal(b, (*map[string]interface{})(h))
+}
+
+// checkDuplicateKeys returns ErrDuplicateHeaderParameter if the JSON
+// object b has duplicate top-level keys. Malformed JSON is left for
+// json.Unmarshal to report.
+func checkDuplicateKeys(b []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil
+	}
+	seen := make(map[string]struct{})
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return nil
+		}
+		if _, ok := seen[key]; ok {
+			return ErrDuplicateHeaderParameter
+		}
+		seen[key] = struct{}{}
+		var v json.RawMessage
+		if err := dec.Decode(&v); err != nil {
+			return nil
+		}
+	}
+	return nil
+}
+
 // Protected Headers are base64-encoded after they're marshaled into
 // JSON.
 type Protected Header
@@ -88,6 +194,40 @@ func (p Protected) Has(key string) bool {
 	return ok
 }
 
+// Algorithm returns the "alg" parameter of the Protected Header.
+func (p Protected) Algorithm() (string, bool) {
+	return getString(p, "alg")
+}
+
+// KeyID returns the "kid" parameter of the Protected Header.
+func (p Protected) KeyID() (string, bool) {
+	return getString(p, "kid")
+}
+
+// SetKeyID sets the "kid" parameter of the Protected Header.
+func (p Protected) SetKeyID(kid string) {
+	p.Set("kid", kid)
+}
+
+// Type returns the "typ" parameter of the Protected Header.
+func (p Protected) Type() (string, bool) {
+	return getString(p, "typ")
+}
+
+// Critical returns the "crit" parameter of the Protected Header.
+func (p Protected) Critical() ([]string, bool) {
+	return getStrings(p, "crit")
+}
+
+// Clone returns a deep copy of the Protected Header. See Header.Clone
+// for more information.
+func (p Protected) Clone() Protected {
+	if p == nil {
+		return nil
+	}
+	return Protected(cloneMap(p))
+}
+
 // MarshalJSON implements json.Marshaler for Protected.
 func (p Protected) MarshalJSON() ([]byte, error) {
 	b, err := json.Marshal(map[string]interface{}(p))
@@ -116,6 +256,83 @@ func (p *Protected) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// UnmarshalJSONStrict is like UnmarshalJSON, but returns
+// ErrDuplicateHeaderParameter if a parameter name appears more than
+// once. See Header.UnmarshalJSONStrict.
+func (p *Protected) UnmarshalJSONStrict(b []byte) error {
+	var h Header
+	if err := h.UnmarshalJSONStrict(b); err != nil {
+		return err
+	}
+	*p = Protected(h)
+	return nil
+}
+
+func cloneMap(m map[string]interface{}) map[string]interface{} {
+	cp := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		cp[k] = clone(v)
+	}
+	return cp
+}
+
+func clone(v interface{}) interface{} {
+	switch t := v.(type) {
+	case Header:
+		return t.Clone()
+	case Protected:
+		return t.Clone()
+	case map[string]interface{}:
+		if t == nil {
+			return t
+		}
+		return cloneMap(t)
+	case []interface{}:
+		if t == nil {
+			return t
+		}
+		cp := make([]interface{}, len(t))
+		for i := range t {
+			cp[i] = clone(t[i])
+		}
+		return cp
+	case []string:
+		if t == nil {
+			return t
+		}
+		cp := make([]string, len(t))
+		copy(cp, t)
+		return cp
+	}
+	return v
+}
+
+// getString returns m[key] if it's a string.
+func getString(m map[string]interface{}, key string) (string, bool) {
+	v, ok := m[key].(string)
+	return v, ok
+}
+
+// getStrings returns m[key] if it's a []string, or an []interface{}
+// consisting only of strings, as it will be after being unmarshaled.
+func getStrings(m map[string]interface{}, key string) ([]string, bool) {
+	switch t := m[key].(type) {
+	case []string:
+		return t, true
+	case []interface{}:
+		s := make([]string, len(t))
+		for i, v := range t {
+			str, ok := v.(string)
+			if !ok {
+				return nil, false
+			}
+			s[i] = str
+		}
+		return s, true
+	}
+	return nil, false
+}
+
 var (
 	_ json.Marshaler   = (Protected)(nil)
 	_ json.Unmarshaler = (*Protected)(nil)
@@ -76,3 +76,122 @@ func TestBasicHeaderFunctions(t *testing.T) {
 		Error(t, nil, v)
 	}
 }
+
+func TestHeaderFields(t *testing.T) {
+	h := Header{}
+	if _, ok := h.KeyID(); ok {
+		t.Error("empty Header should not have a kid")
+	}
+
+	h.SetType("JWT")
+	h.SetContentType("example")
+	h.SetKeyID("key")
+	h.Set("alg", "RS256")
+	h.Set("crit", []interface{}{"exp"})
+
+	if v, _ := h.Type(); v != "JWT" {
+		Error(t, "JWT", v)
+	}
+	if v, _ := h.ContentType(); v != "example" {
+		Error(t, "example", v)
+	}
+	if v, _ := h.KeyID(); v != "key" {
+		Error(t, "key", v)
+	}
+	if v, _ := h.Algorithm(); v != "RS256" {
+		Error(t, "RS256", v)
+	}
+	if v, ok := h.Critical(); !ok || len(v) != 1 || v[0] != "exp" {
+		Error(t, []string{"exp"}, v)
+	}
+
+	h.Set("kid", 1)
+	if _, ok := h.KeyID(); ok {
+		t.Error("non-string kid should not be returned")
+	}
+	h.Set("crit", []interface{}{"exp", 1})
+	if _, ok := h.Critical(); ok {
+		t.Error("non-string crit member should not be returned")
+	}
+}
+
+func TestProtectedFields(t *testing.T) {
+	p := Protected{
+		"alg":  "ES256",
+		"typ":  "JWT",
+		"crit": []string{"b64"},
+	}
+	p.SetKeyID("key")
+
+	if v, _ := p.Algorithm(); v != "ES256" {
+		Error(t, "ES256", v)
+	}
+	if v, _ := p.KeyID(); v != "key" {
+		Error(t, "key", v)
+	}
+	if v, _ := p.Type(); v != "JWT" {
+		Error(t, "JWT", v)
+	}
+	if v, ok := p.Critical(); !ok || len(v) != 1 || v[0] != "b64" {
+		Error(t, []string{"b64"}, v)
+	}
+}
+
+func TestClone(t *testing.T) {
+	h := Header{
+		"crit": []string{"exp"},
+		"jwk":  map[string]interface{}{"kty": "EC"},
+	}
+	h2 := h.Clone()
+	h2["crit"].([]string)[0] = "nbf"
+	h2["jwk"].(map[string]interface{})["kty"] = "RSA"
+	h2.SetKeyID("key")
+
+	if v := h["crit"].([]string)[0]; v != "exp" {
+		Error(t, "exp", v)
+	}
+	if v := h["jwk"].(map[string]interface{})["kty"]; v != "EC" {
+		Error(t, "EC", v)
+	}
+	if h.Has("kid") {
+		t.Error("modifying the clone should not modify the original")
+	}
+
+	p := Protected{"crit": []interface{}{"exp"}}
+	p2 := p.Clone()
+	p2["crit"].([]interface{})[0] = "nbf"
+	if v := p["crit"].([]interface{})[0]; v != "exp" {
+		Error(t, "exp", v)
+	}
+
+	if Header(nil).Clone() != nil || Protected(nil).Clone() != nil {
+		t.Error("cloning nil should return nil")
+	}
+}
+
+func TestUnmarshalJSONStrict(t *testing.T) {
+	b := Base64Encode([]byte(`{"alg":"HS256","typ":"JWT","alg":"none"}`))
+
+	var h Header
+	if err := h.UnmarshalJSONStrict(b); err != ErrDuplicateHeaderParameter {
+		t.Errorf("wanted %v, got %v", ErrDuplicateHeaderParameter, err)
+	}
+	var p Protected
+	if err := p.UnmarshalJSONStrict(b); err != ErrDuplicateHeaderParameter {
+		t.Errorf("wanted %v, got %v", ErrDuplicateHeaderParameter, err)
+	}
+
+	// UnmarshalJSON keeps the last value.
+	if err := p.UnmarshalJSON(b); err != nil {
+		t.Fatal(err)
+	}
+	if alg := p.Get("alg"); alg != "none" {
+		t.Errorf("wanted %q, got %q", "none", alg)
+	}
+
+	// Nested objects may reuse top-level names.
+	b = Base64Encode([]byte(`{"alg":"HS256","jwk":{"alg":"HS256"}}`))
+	if err := p.UnmarshalJSONStrict(b); err != nil {
+		t.Error(err)
+	}
+}
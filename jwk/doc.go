@@ -0,0 +1,2 @@
+// Package jwk implements JWKs per RFC 7517
+package jwk
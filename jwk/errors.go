@@ -0,0 +1,41 @@
+package jwk
+
+import "errors"
+
+var (
+	// ErrUnsupportedKeyType means the JWK's "kty" parameter isn't one of
+	// "RSA", "EC", "OKP", or "oct".
+	ErrUnsupportedKeyType = errors.New("unsupported key type")
+
+	// ErrUnsupportedCurve means the JWK's "crv" parameter isn't a
+	// supported curve for its key type.
+	ErrUnsupportedCurve = errors.New("unsupported curve")
+
+	// ErrInvalidKey means the JWK's key parameters are missing or
+	// malformed.
+	ErrInvalidKey = errors.New("invalid key parameters")
+
+	// ErrNoPublicKey means the JWK, e.g. a symmetric key, doesn't have a
+	// public key.
+	ErrNoPublicKey = errors.New("JWK has no public key")
+
+	// ErrNoPrivateKey means the JWK doesn't have private key material.
+	ErrNoPrivateKey = errors.New("JWK has no private key")
+
+	// ErrCertificateMismatch means the public key of the first
+	// certificate in the JWK's "x5c" parameter doesn't match the JWK's
+	// key parameters.
+	ErrCertificateMismatch = errors.New("certificate doesn't match JWK")
+
+	// ErrKeyNotFound means a JWK Set doesn't contain a key that can
+	// verify the JWS.
+	ErrKeyNotFound = errors.New("no matching key in JWK Set")
+
+	// ErrHashUnavailable means the crypto.Hash passed to Thumbprint
+	// isn't linked into the binary.
+	ErrHashUnavailable = errors.New("hash function is unavailable")
+
+	// ErrUnexpectedStatus means the server hosting a JWK Set responded
+	// with a status other than 200 OK.
+	ErrUnexpectedStatus = errors.New("unexpected HTTP status fetching JWK Set")
+)
@@ -0,0 +1,224 @@
+package jwk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FetchSet retrieves the JWK Set located at url. If client is nil,
+// http.DefaultClient is used.
+func FetchSet(ctx context.Context, url string, client *http.Client) (*JWKSet, error) {
+	s, _, err := fetchSet(ctx, url, client)
+	return s, err
+}
+
+// fetchSet retrieves the JWK Set located at url and returns it along
+// with the response's Cache-Control max-age directive, or -1 if it
+// doesn't have one.
+func fetchSet(ctx context.Context, url string, client *http.Client) (*JWKSet, time.Duration, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, ErrUnexpectedStatus
+	}
+	var s JWKSet
+	if err := json.NewDecoder(resp.Body).Decode(&s); err != nil {
+		return nil, 0, err
+	}
+	return &s, maxAge(resp.Header.Get("Cache-Control")), nil
+}
+
+// maxAge returns the max-age directive of the Cache-Control header cc,
+// or -1 if it doesn't have a valid one.
+func maxAge(cc string) time.Duration {
+	for _, dir := range strings.Split(cc, ",") {
+		dir = strings.TrimSpace(dir)
+		if len(dir) < 8 || !strings.EqualFold(dir[:8], "max-age=") {
+			continue
+		}
+		secs, err := strconv.ParseInt(strings.Trim(dir[8:], `"`), 10, 64)
+		if err != nil || secs < 0 {
+			return -1
+		}
+		return time.Duration(secs) * time.Second
+	}
+	return -1
+}
+
+// DefaultTTL is the duration a CachingFetcher caches a JWK Set whose
+// response doesn't have a Cache-Control max-age directive.
+const DefaultTTL = time.Hour
+
+// DefaultFetchTimeout bounds each fetch made by a CachingFetcher whose
+// Timeout is zero.
+const DefaultFetchTimeout = 30 * time.Second
+
+// Failed background refreshes are retried with exponential backoff,
+// starting at minRetryDelay and capped at maxRetryDelay.
+const (
+	minRetryDelay = time.Second
+	maxRetryDelay = 5 * time.Minute
+)
+
+// CachingFetcher fetches a JWK Set with FetchSet and caches it for
+// the duration of the response's Cache-Control max-age directive, or
+// TTL if it doesn't have one.
+//
+// Once three quarters of the cached set's lifetime has passed, Get
+// refreshes it in the background and continues to return the cached
+// set until the refresh finishes or the set expires. If the refresh
+// fails, it's retried with exponential backoff.
+//
+// Only one fetch is in flight at a time. Concurrent calls to Get share
+// its result, and each stops waiting once its own context is done.
+type CachingFetcher struct {
+	// URL is the location of the JWK Set.
+	URL string
+
+	// Client is used to fetch the JWK Set. If nil, http.DefaultClient
+	// is used.
+	Client *http.Client
+
+	// TTL is the fallback duration for which the JWK Set is cached. If
+	// zero, DefaultTTL is used.
+	TTL time.Duration
+
+	// Timeout bounds each fetch of the JWK Set. If zero,
+	// DefaultFetchTimeout is used.
+	Timeout time.Duration
+
+	mu       sync.Mutex
+	set      *JWKSet
+	refresh  time.Time
+	expires  time.Time
+	inflight *fetchCall
+	retry    time.Time
+	failures int
+
+	now func() time.Time // for testing
+}
+
+// fetchCall is a fetch shared by concurrent callers of Get. done is
+// closed once set and err are populated.
+type fetchCall struct {
+	done chan struct{}
+	set  *JWKSet
+	err  error
+}
+
+// NewCachingFetcher returns a CachingFetcher for the JWK Set located at
+// url.
+func NewCachingFetcher(url string, client *http.Client, ttl time.Duration) *CachingFetcher {
+	return &CachingFetcher{URL: url, Client: client, TTL: ttl}
+}
+
+// Get returns the cached JWK Set, fetching it if it hasn't been fetched
+// yet or has expired. If ctx is done before the fetch finishes, ctx's
+// error is returned, but the fetch continues for other callers.
+func (f *CachingFetcher) Get(ctx context.Context) (*JWKSet, error) {
+	f.mu.Lock()
+	now := f.clock()
+	if f.set != nil && now.Before(f.expires) {
+		s := f.set
+		if !now.Before(f.refresh) && !now.Before(f.retry) && f.inflight == nil {
+			f.start()
+		}
+		f.mu.Unlock()
+		return s, nil
+	}
+	c := f.inflight
+	if c == nil {
+		c = f.start()
+	}
+	f.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.set, c.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// start begins fetching the JWK Set in the background. The caller must
+// hold f.mu.
+func (f *CachingFetcher) start() *fetchCall {
+	c := &fetchCall{done: make(chan struct{})}
+	f.inflight = c
+	go f.fetch(c)
+	return c
+}
+
+func (f *CachingFetcher) fetch(c *fetchCall) {
+	timeout := f.Timeout
+	if timeout <= 0 {
+		timeout = DefaultFetchTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	s, age, err := fetchSet(ctx, f.URL, f.Client)
+	cancel()
+
+	f.mu.Lock()
+	now := f.clock()
+	if err == nil {
+		f.store(s, age, now)
+		f.failures = 0
+	} else {
+		f.retry = now.Add(retryDelay(f.failures))
+		f.failures++
+	}
+	f.inflight = nil
+	c.set, c.err = s, err
+	f.mu.Unlock()
+	close(c.done)
+}
+
+// retryDelay returns the backoff after the given number of consecutive
+// failures.
+func retryDelay(failures int) time.Duration {
+	d := minRetryDelay
+	for i := 0; i < failures && d < maxRetryDelay; i++ {
+		d *= 2
+	}
+	if d > maxRetryDelay {
+		d = maxRetryDelay
+	}
+	return d
+}
+
+// store caches s for age, or the fallback TTL if age is negative. The
+// caller must hold f.mu.
+func (f *CachingFetcher) store(s *JWKSet, age time.Duration, now time.Time) {
+	if age < 0 {
+		age = f.TTL
+		if age <= 0 {
+			age = DefaultTTL
+		}
+	}
+	f.set = s
+	f.refresh = now.Add(age * 3 / 4)
+	f.expires = now.Add(age)
+}
+
+func (f *CachingFetcher) clock() time.Time {
+	if f.now != nil {
+		return f.now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,262 @@
+package jwk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newSetServer(cc string, hits *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(hits, 1)
+		if cc != "" {
+			w.Header().Set("Cache-Control", cc)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(rfcSet))
+	}))
+}
+
+// fakeClock is a settable clock safe for use by background refreshes.
+type fakeClock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.mu.Lock()
+	c.t = c.t.Add(d)
+	c.mu.Unlock()
+}
+
+// waitIdle waits for f's in-flight fetch, if any, to finish.
+func waitIdle(t *testing.T, f *CachingFetcher) {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		f.mu.Lock()
+		done := f.inflight == nil
+		f.mu.Unlock()
+		if done {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("fetch didn't finish")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestFetchSet(t *testing.T) {
+	var hits int32
+	srv := newSetServer("", &hits)
+	defer srv.Close()
+
+	s, err := FetchSet(context.Background(), srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Keys) != 2 {
+		t.Fatalf("got %d keys, wanted 2", len(s.Keys))
+	}
+
+	nf := httptest.NewServer(http.NotFoundHandler())
+	defer nf.Close()
+	if _, err := FetchSet(context.Background(), nf.URL, nil); err != ErrUnexpectedStatus {
+		t.Fatalf("got %v, wanted %v", err, ErrUnexpectedStatus)
+	}
+}
+
+func TestMaxAge(t *testing.T) {
+	for cc, want := range map[string]time.Duration{
+		"":                          -1,
+		"no-cache":                  -1,
+		"max-age=60":                time.Minute,
+		"public, MAX-AGE=3600":      time.Hour,
+		"public, max-age=\"120\"":   2 * time.Minute,
+		"max-age=-1":                -1,
+		"s-maxage=5, max-age=bogus": -1,
+	} {
+		if got := maxAge(cc); got != want {
+			t.Errorf("maxAge(%q): got %v, wanted %v", cc, got, want)
+		}
+	}
+}
+
+func TestCachingFetcher(t *testing.T) {
+	var hits int32
+	srv := newSetServer("max-age=100", &hits)
+	defer srv.Close()
+
+	clock := &fakeClock{t: time.Now()}
+	f := NewCachingFetcher(srv.URL, nil, time.Minute)
+	f.now = clock.now
+
+	for i := 0; i < 3; i++ {
+		if _, err := f.Get(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := atomic.LoadInt32(&hits); n != 1 {
+		t.Fatalf("got %d fetches, wanted 1", n)
+	}
+
+	// Past the refresh point: the cached set is returned and refreshed
+	// in the background.
+	clock.advance(80 * time.Second)
+	if _, err := f.Get(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	waitIdle(t, f)
+	if n := atomic.LoadInt32(&hits); n != 2 {
+		t.Fatalf("got %d fetches, wanted 2", n)
+	}
+
+	// Past expiry: the set is fetched synchronously.
+	clock.advance(time.Hour)
+	if _, err := f.Get(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(&hits); n != 3 {
+		t.Fatalf("got %d fetches, wanted 3", n)
+	}
+}
+
+func TestCachingFetcherTTL(t *testing.T) {
+	var hits int32
+	srv := newSetServer("", &hits)
+	defer srv.Close()
+
+	now := time.Now()
+	f := NewCachingFetcher(srv.URL, nil, time.Minute)
+	f.now = func() time.Time { return now }
+
+	if _, err := f.Get(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if want := now.Add(time.Minute); !f.expires.Equal(want) {
+		t.Fatalf("got expiry %v, wanted %v", f.expires, want)
+	}
+}
+
+func TestCachingFetcherConcurrent(t *testing.T) {
+	var hits int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		<-release
+		w.Write([]byte(rfcSet))
+	}))
+	defer srv.Close()
+
+	f := NewCachingFetcher(srv.URL, nil, time.Minute)
+
+	// A caller whose context expires stops waiting without affecting
+	// the fetch.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := f.Get(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("got %v, wanted %v", err, context.DeadlineExceeded)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s, err := f.Get(context.Background())
+			if err == nil && len(s.Keys) != 2 {
+				t.Errorf("got %d keys, wanted 2", len(s.Keys))
+			}
+			errs <- err
+		}()
+	}
+	close(release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+	if n := atomic.LoadInt32(&hits); n != 1 {
+		t.Fatalf("got %d fetches, wanted 1", n)
+	}
+}
+
+func TestCachingFetcherTimeout(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	f := NewCachingFetcher(srv.URL, nil, time.Minute)
+	f.Timeout = 10 * time.Millisecond
+	if _, err := f.Get(context.Background()); err == nil {
+		t.Fatal("wanted an error from a hung server")
+	}
+	waitIdle(t, f)
+}
+
+func TestCachingFetcherRetryBackoff(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&hits, 1) > 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(rfcSet))
+	}))
+	defer srv.Close()
+
+	clock := &fakeClock{t: time.Now()}
+	f := NewCachingFetcher(srv.URL, nil, 100*time.Second)
+	f.now = clock.now
+
+	if _, err := f.Get(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// The background refresh fails, and the cached set is still used.
+	clock.advance(80 * time.Second)
+	if _, err := f.Get(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	waitIdle(t, f)
+	if n := atomic.LoadInt32(&hits); n != 2 {
+		t.Fatalf("got %d fetches, wanted 2", n)
+	}
+
+	// It isn't retried until the backoff has passed.
+	if _, err := f.Get(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	waitIdle(t, f)
+	if n := atomic.LoadInt32(&hits); n != 2 {
+		t.Fatalf("got %d fetches, wanted 2", n)
+	}
+
+	clock.advance(minRetryDelay)
+	if _, err := f.Get(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	waitIdle(t, f)
+	if n := atomic.LoadInt32(&hits); n != 3 {
+		t.Fatalf("got %d fetches, wanted 3", n)
+	}
+	if d := retryDelay(f.failures); d != 4*minRetryDelay {
+		t.Fatalf("got backoff %v, wanted %v", d, 4*minRetryDelay)
+	}
+}
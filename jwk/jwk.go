@@ -0,0 +1,404 @@
+package jwk
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/json"
+	"math/big"
+
+	"github.com/SermoDigital/jose"
+)
+
+// JWK is a JSON Web Key per
+// https://tools.ietf.org/html/rfc7517#section-4
+//
+// Key parameters are stored as raw, base64url-decoded bytes. Which
+// parameters are used depends on the key type:
+//
+//	RSA: N, E, D, P, Q
+//	EC:  Crv, X, Y, D
+//	OKP: Crv, X, D
+//	oct: K
+type JWK struct {
+	KeyType   string   // "kty"
+	Use       string   // "use"
+	KeyOps    []string // "key_ops"
+	Algorithm string   // "alg"
+	KeyID     string   // "kid"
+
+	// X5C is the "x5c" parameter: the standard base64-encoded DER
+	// certificates, the first of which contains the JWK's public key.
+	X5C []string
+
+	N, E, P, Q []byte // RSA parameters.
+	Crv        string // EC and OKP curve.
+	X, Y       []byte // EC and OKP parameters.
+	D          []byte // RSA, EC, and OKP private key.
+	K          []byte // Symmetric key.
+}
+
+// jwk is the JSON representation of a JWK.
+type jwk struct {
+	KeyType   string   `json:"kty"`
+	Use       string   `json:"use,omitempty"`
+	KeyOps    []string `json:"key_ops,omitempty"`
+	Algorithm string   `json:"alg,omitempty"`
+	KeyID     string   `json:"kid,omitempty"`
+	X5C       []string `json:"x5c,omitempty"`
+
+	Crv string `json:"crv,omitempty"`
+	N   param  `json:"n,omitempty"`
+	E   param  `json:"e,omitempty"`
+	X   param  `json:"x,omitempty"`
+	Y   param  `json:"y,omitempty"`
+	D   param  `json:"d,omitempty"`
+	P   param  `json:"p,omitempty"`
+	Q   param  `json:"q,omitempty"`
+	K   param  `json:"k,omitempty"`
+}
+
+// param is a base64url-encoded key parameter.
+type param []byte
+
+// MarshalText implements encoding.TextMarshaler for param.
+func (p param) MarshalText() ([]byte, error) {
+	return jose.Base64Encode(p), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for param.
+func (p *param) UnmarshalText(b []byte) error {
+	dec, err := jose.Base64Decode(b)
+	if err != nil {
+		return err
+	}
+	*p = param(dec)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler for JWK.
+func (k JWK) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jwk{
+		KeyType:   k.KeyType,
+		Use:       k.Use,
+		KeyOps:    k.KeyOps,
+		Algorithm: k.Algorithm,
+		KeyID:     k.KeyID,
+		X5C:       k.X5C,
+		Crv:       k.Crv,
+		N:         k.N,
+		E:         k.E,
+		X:         k.X,
+		Y:         k.Y,
+		D:         k.D,
+		P:         k.P,
+		Q:         k.Q,
+		K:         k.K,
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler for JWK.
+func (k *JWK) UnmarshalJSON(b []byte) error {
+	var j jwk
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+	*k = JWK{
+		KeyType:   j.KeyType,
+		Use:       j.Use,
+		KeyOps:    j.KeyOps,
+		Algorithm: j.Algorithm,
+		KeyID:     j.KeyID,
+		X5C:       j.X5C,
+		Crv:       j.Crv,
+		N:         j.N,
+		E:         j.E,
+		X:         j.X,
+		Y:         j.Y,
+		D:         j.D,
+		P:         j.P,
+		Q:         j.Q,
+		K:         j.K,
+	}
+	return nil
+}
+
+// NewJWK returns a JWK for key, which must be an *rsa.PublicKey,
+// *rsa.PrivateKey, *ecdsa.PublicKey, *ecdsa.PrivateKey,
+// ed25519.PublicKey, ed25519.PrivateKey, or []byte for symmetric keys.
+func NewJWK(key interface{}) (*JWK, error) {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return rsaJWK(k), nil
+	case *rsa.PrivateKey:
+		if len(k.Primes) != 2 {
+			return nil, ErrInvalidKey
+		}
+		j := rsaJWK(&k.PublicKey)
+		j.D = k.D.Bytes()
+		j.P = k.Primes[0].Bytes()
+		j.Q = k.Primes[1].Bytes()
+		return j, nil
+	case *ecdsa.PublicKey:
+		return ecdsaJWK(k)
+	case *ecdsa.PrivateKey:
+		j, err := ecdsaJWK(&k.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		j.D = k.D.FillBytes(make([]byte, len(j.X)))
+		return j, nil
+	case ed25519.PublicKey:
+		if len(k) != ed25519.PublicKeySize {
+			return nil, ErrInvalidKey
+		}
+		return &JWK{KeyType: "OKP", Crv: "Ed25519", X: clone(k)}, nil
+	case ed25519.PrivateKey:
+		if len(k) != ed25519.PrivateKeySize {
+			return nil, ErrInvalidKey
+		}
+		return &JWK{
+			KeyType: "OKP",
+			Crv:     "Ed25519",
+			X:       clone(k.Public().(ed25519.PublicKey)),
+			D:       clone(k.Seed()),
+		}, nil
+	case []byte:
+		return &JWK{KeyType: "oct", K: clone(k)}, nil
+	}
+	return nil, ErrUnsupportedKeyType
+}
+
+func rsaJWK(k *rsa.PublicKey) *JWK {
+	return &JWK{
+		KeyType: "RSA",
+		N:       k.N.Bytes(),
+		E:       big.NewInt(int64(k.E)).Bytes(),
+	}
+}
+
+func ecdsaJWK(k *ecdsa.PublicKey) (*JWK, error) {
+	var crv string
+	for name, c := range curves {
+		if c == k.Curve {
+			crv = name
+			break
+		}
+	}
+	if crv == "" {
+		return nil, ErrUnsupportedCurve
+	}
+	size := (k.Curve.Params().BitSize + 7) / 8
+	return &JWK{
+		KeyType: "EC",
+		Crv:     crv,
+		X:       k.X.FillBytes(make([]byte, size)),
+		Y:       k.Y.FillBytes(make([]byte, size)),
+	}, nil
+}
+
+func clone(b []byte) []byte {
+	return append([]byte(nil), b...)
+}
+
+// PublicKey returns the JWK's public key as an *rsa.PublicKey,
+// *ecdsa.PublicKey, or ed25519.PublicKey. It returns ErrNoPublicKey for
+// symmetric keys.
+func (k *JWK) PublicKey() (crypto.PublicKey, error) {
+	switch k.KeyType {
+	case "RSA":
+		return k.rsaPublicKey()
+	case "EC":
+		return k.ecdsaPublicKey()
+	case "OKP":
+		return k.ed25519PublicKey()
+	case "oct":
+		return nil, ErrNoPublicKey
+	}
+	return nil, ErrUnsupportedKeyType
+}
+
+func (k *JWK) rsaPublicKey() (*rsa.PublicKey, error) {
+	if len(k.N) == 0 || len(k.E) == 0 {
+		return nil, ErrInvalidKey
+	}
+	e := new(big.Int).SetBytes(k.E)
+	if !e.IsInt64() || e.Int64() > 1<<31-1 {
+		return nil, ErrInvalidKey
+	}
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(k.N),
+		E: int(e.Int64()),
+	}, nil
+}
+
+func (k *JWK) ecdsaPublicKey() (*ecdsa.PublicKey, error) {
+	curve, ok := curves[k.Crv]
+	if !ok {
+		return nil, ErrUnsupportedCurve
+	}
+	size := (curve.Params().BitSize + 7) / 8
+	if len(k.X) != size || len(k.Y) != size {
+		return nil, ErrInvalidKey
+	}
+	x := new(big.Int).SetBytes(k.X)
+	y := new(big.Int).SetBytes(k.Y)
+	if !curve.IsOnCurve(x, y) {
+		return nil, ErrInvalidKey
+	}
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
+func (k *JWK) ed25519PublicKey() (ed25519.PublicKey, error) {
+	if k.Crv != "Ed25519" {
+		return nil, ErrUnsupportedCurve
+	}
+	if len(k.X) != ed25519.PublicKeySize {
+		return nil, ErrInvalidKey
+	}
+	return ed25519.PublicKey(k.X), nil
+}
+
+// PrivateKey returns the JWK's private key as an *rsa.PrivateKey,
+// *ecdsa.PrivateKey, or ed25519.PrivateKey. It returns ErrNoPrivateKey
+// if the JWK has no private key material, including symmetric keys.
+func (k *JWK) PrivateKey() (crypto.PrivateKey, error) {
+	switch k.KeyType {
+	case "RSA", "EC", "OKP":
+		if len(k.D) == 0 {
+			return nil, ErrNoPrivateKey
+		}
+	case "oct":
+		return nil, ErrNoPrivateKey
+	}
+	switch k.KeyType {
+	case "RSA":
+		return k.rsaPrivateKey()
+	case "EC":
+		return k.ecdsaPrivateKey()
+	case "OKP":
+		return k.ed25519PrivateKey()
+	}
+	return nil, ErrUnsupportedKeyType
+}
+
+func (k *JWK) rsaPrivateKey() (*rsa.PrivateKey, error) {
+	pub, err := k.rsaPublicKey()
+	if err != nil {
+		return nil, err
+	}
+	if len(k.P) == 0 || len(k.Q) == 0 {
+		return nil, ErrInvalidKey
+	}
+	priv := &rsa.PrivateKey{
+		PublicKey: *pub,
+		D:         new(big.Int).SetBytes(k.D),
+		Primes: []*big.Int{
+			new(big.Int).SetBytes(k.P),
+			new(big.Int).SetBytes(k.Q),
+		},
+	}
+	if err := priv.Validate(); err != nil {
+		return nil, ErrInvalidKey
+	}
+	priv.Precompute()
+	return priv, nil
+}
+
+func (k *JWK) ecdsaPrivateKey() (*ecdsa.PrivateKey, error) {
+	pub, err := k.ecdsaPublicKey()
+	if err != nil {
+		return nil, err
+	}
+	d := new(big.Int).SetBytes(k.D)
+	if d.Sign() == 0 || d.Cmp(pub.Curve.Params().N) >= 0 {
+		return nil, ErrInvalidKey
+	}
+	x, y := pub.Curve.ScalarBaseMult(k.D)
+	if x.Cmp(pub.X) != 0 || y.Cmp(pub.Y) != 0 {
+		return nil, ErrInvalidKey
+	}
+	return &ecdsa.PrivateKey{PublicKey: *pub, D: d}, nil
+}
+
+func (k *JWK) ed25519PrivateKey() (ed25519.PrivateKey, error) {
+	pub, err := k.ed25519PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	if len(k.D) != ed25519.SeedSize {
+		return nil, ErrInvalidKey
+	}
+	priv := ed25519.NewKeyFromSeed(k.D)
+	if !pub.Equal(priv.Public()) {
+		return nil, ErrInvalidKey
+	}
+	return priv, nil
+}
+
+// curves maps "crv" parameters to their elliptic.Curves, per
+// https://tools.ietf.org/html/rfc7518#section-6.2.1.1
+var curves = map[string]elliptic.Curve{
+	"P-256": elliptic.P256(),
+	"P-384": elliptic.P384(),
+	"P-521": elliptic.P521(),
+}
+
+// JWKSet is a JWK Set per
+// https://tools.ietf.org/html/rfc7517#section-5
+type JWKSet struct {
+	Keys []JWK
+}
+
+// MarshalJSON implements json.Marshaler for JWKSet.
+func (s JWKSet) MarshalJSON() ([]byte, error) {
+	keys := s.Keys
+	if keys == nil {
+		keys = []JWK{}
+	}
+	return json.Marshal(struct {
+		Keys []JWK `json:"keys"`
+	}{keys})
+}
+
+// UnmarshalJSON implements json.Unmarshaler for JWKSet.
+func (s *JWKSet) UnmarshalJSON(b []byte) error {
+	var set struct {
+		Keys []JWK `json:"keys"`
+	}
+	if err := json.Unmarshal(b, &set); err != nil {
+		return err
+	}
+	s.Keys = set.Keys
+	return nil
+}
+
+// GetByKID returns the first JWK in s whose "kid" parameter is kid.
+func (s *JWKSet) GetByKID(kid string) (*JWK, bool) {
+	for i := range s.Keys {
+		if s.Keys[i].KeyID == kid {
+			return &s.Keys[i], true
+		}
+	}
+	return nil, false
+}
+
+// GetByAlg returns every JWK in s whose "alg" parameter is alg.
+func (s *JWKSet) GetByAlg(alg string) []*JWK {
+	var keys []*JWK
+	for i := range s.Keys {
+		if s.Keys[i].Algorithm == alg {
+			keys = append(keys, &s.Keys[i])
+		}
+	}
+	return keys
+}
+
+var (
+	_ json.Marshaler   = JWK{}
+	_ json.Unmarshaler = (*JWK)(nil)
+	_ json.Marshaler   = JWKSet{}
+	_ json.Unmarshaler = (*JWKSet)(nil)
+)
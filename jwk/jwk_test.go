@@ -0,0 +1,256 @@
+package jwk
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"testing"
+)
+
+// rfcSet is the example public keys from
+// https://tools.ietf.org/html/rfc7517#appendix-A.1
+const rfcSet = `{"keys":
+  [
+    {"kty":"EC",
+     "crv":"P-256",
+     "x":"MKBCTNIcKUSDii11ySs3526iDZ8AiTo7Tu6KPAqv7D4",
+     "y":"4Etl6SRW2YiLUrN5vfvVHuhp7x8PxltmWWlbbM4IFyM",
+     "use":"enc",
+     "kid":"1"},
+
+    {"kty":"RSA",
+     "n": "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw",
+     "e":"AQAB",
+     "alg":"RS256",
+     "kid":"2011-04-29"}
+  ]
+}`
+
+func TestJWKSet(t *testing.T) {
+	var s JWKSet
+	if err := json.Unmarshal([]byte(rfcSet), &s); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Keys) != 2 {
+		t.Fatalf("wanted 2 keys, got %d", len(s.Keys))
+	}
+
+	pub, err := s.Keys[0].PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := pub.(*ecdsa.PublicKey); !ok {
+		t.Errorf("wanted *ecdsa.PublicKey, got %T", pub)
+	}
+
+	pub, err = s.Keys[1].PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, ok := pub.(*rsa.PublicKey)
+	if !ok {
+		t.Fatalf("wanted *rsa.PublicKey, got %T", pub)
+	}
+	if rsaKey.E != 65537 || rsaKey.N.BitLen() != 2048 {
+		t.Errorf("unexpected RSA key: e=%d, %d bits", rsaKey.E, rsaKey.N.BitLen())
+	}
+
+	b, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var s2 JWKSet
+	if err := json.Unmarshal(b, &s2); err != nil {
+		t.Fatal(err)
+	}
+	if s2.Keys[1].KeyID != "2011-04-29" || !bytes.Equal(s2.Keys[1].N, s.Keys[1].N) {
+		t.Error("JWKSet did not round-trip")
+	}
+}
+
+func TestPublicKeyOKP(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k := JWK{KeyType: "OKP", Crv: "Ed25519", X: pub}
+	got, err := k.PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pub.Equal(got) {
+		t.Error("Ed25519 public key doesn't match")
+	}
+}
+
+func TestPublicKeyErrors(t *testing.T) {
+	for _, tt := range []struct {
+		k   JWK
+		err error
+	}{
+		{JWK{KeyType: "oct", K: []byte("secret")}, ErrNoPublicKey},
+		{JWK{KeyType: "foo"}, ErrUnsupportedKeyType},
+		{JWK{KeyType: "EC", Crv: "P-192"}, ErrUnsupportedCurve},
+		{JWK{KeyType: "EC", Crv: "P-256", X: make([]byte, 32), Y: make([]byte, 32)}, ErrInvalidKey},
+		{JWK{KeyType: "RSA"}, ErrInvalidKey},
+		{JWK{KeyType: "OKP", Crv: "X25519"}, ErrUnsupportedCurve},
+	} {
+		if _, err := tt.k.PublicKey(); err != tt.err {
+			t.Errorf("%s: wanted %v, got %v", tt.k.KeyType, tt.err, err)
+		}
+	}
+}
+
+func TestJWKSetGet(t *testing.T) {
+	var s JWKSet
+	if err := json.Unmarshal([]byte(rfcSet), &s); err != nil {
+		t.Fatal(err)
+	}
+
+	k, ok := s.GetByKID("2011-04-29")
+	if !ok || k.KeyType != "RSA" {
+		t.Errorf("GetByKID returned %v, %v", k, ok)
+	}
+	if _, ok := s.GetByKID("missing"); ok {
+		t.Error("GetByKID should not find a missing kid")
+	}
+
+	if keys := s.GetByAlg("RS256"); len(keys) != 1 || keys[0] != &s.Keys[1] {
+		t.Errorf("GetByAlg returned %v", keys)
+	}
+	if keys := s.GetByAlg("ES256"); len(keys) != 0 {
+		t.Errorf("GetByAlg returned %v", keys)
+	}
+}
+
+func TestNewJWK(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPub, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type publicKey interface {
+		Equal(x crypto.PublicKey) bool
+	}
+	for _, tt := range []struct {
+		key     interface{}
+		pub     publicKey
+		private bool
+	}{
+		{&rsaKey.PublicKey, &rsaKey.PublicKey, false},
+		{rsaKey, &rsaKey.PublicKey, true},
+		{&ecKey.PublicKey, &ecKey.PublicKey, false},
+		{ecKey, &ecKey.PublicKey, true},
+		{edPub, edPub, false},
+		{edPriv, edPub, true},
+	} {
+		k, err := NewJWK(tt.key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (k.D != nil) != tt.private {
+			t.Errorf("%s: wanted private key material: %t", k.KeyType, tt.private)
+		}
+
+		// Round-trip through JSON to make sure the parameters survive.
+		b, err := json.Marshal(k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var k2 JWK
+		if err := json.Unmarshal(b, &k2); err != nil {
+			t.Fatal(err)
+		}
+		pub, err := k2.PublicKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !tt.pub.Equal(pub) {
+			t.Errorf("%s: public key doesn't match", k.KeyType)
+		}
+	}
+
+	k, err := NewJWK([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k.KeyType != "oct" || string(k.K) != "secret" {
+		t.Errorf("got %+v", k)
+	}
+
+	if _, err := NewJWK("secret"); err != ErrUnsupportedKeyType {
+		t.Errorf("wanted %v, got %v", ErrUnsupportedKeyType, err)
+	}
+	ec224, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewJWK(ec224); err != ErrUnsupportedCurve {
+		t.Errorf("wanted %v, got %v", ErrUnsupportedCurve, err)
+	}
+}
+
+func TestPrivateKey(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type privateKey interface {
+		Public() crypto.PublicKey
+		Equal(x crypto.PrivateKey) bool
+	}
+	for _, key := range []privateKey{rsaKey, ecKey, edKey} {
+		k, err := NewJWK(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		priv, err := k.PrivateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !key.Equal(priv) {
+			t.Errorf("%s: private key doesn't match", k.KeyType)
+		}
+
+		pk, err := NewJWK(key.Public())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := pk.PrivateKey(); err != ErrNoPrivateKey {
+			t.Errorf("%s: wanted %v, got %v", k.KeyType, ErrNoPrivateKey, err)
+		}
+
+		// Private key material that doesn't match the public key.
+		k.D[len(k.D)-1] ^= 1
+		if _, err := k.PrivateKey(); err != ErrInvalidKey {
+			t.Errorf("%s: wanted %v, got %v", k.KeyType, ErrInvalidKey, err)
+		}
+	}
+
+	oct := JWK{KeyType: "oct", K: []byte("secret")}
+	if _, err := oct.PrivateKey(); err != ErrNoPrivateKey {
+		t.Errorf("oct: wanted %v, got %v", ErrNoPrivateKey, err)
+	}
+}
@@ -0,0 +1,89 @@
+package jwk
+
+import (
+	"bytes"
+	"crypto"
+	_ "crypto/sha256" // Required by ThumbprintURI.
+	"encoding/json"
+
+	"github.com/SermoDigital/jose"
+)
+
+// Thumbprint returns the JWK's thumbprint per
+// https://tools.ietf.org/html/rfc7638, computed with hash.
+func (k *JWK) Thumbprint(hash crypto.Hash) ([]byte, error) {
+	if !hash.Available() {
+		return nil, ErrHashUnavailable
+	}
+	b, err := k.thumbprintInput()
+	if err != nil {
+		return nil, err
+	}
+	h := hash.New()
+	h.Write(b)
+	return h.Sum(nil), nil
+}
+
+// ThumbprintURI returns the JWK's SHA-256 thumbprint as a URI per
+// https://tools.ietf.org/html/rfc9278
+func (k *JWK) ThumbprintURI() (string, error) {
+	t, err := k.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", err
+	}
+	return "urn:ietf:params:oauth:jwk-thumbprint:sha-256:" + string(jose.Base64Encode(t)), nil
+}
+
+// thumbprintInput returns the JSON object containing only the JWK's
+// required members, in lexicographic order and without whitespace.
+func (k *JWK) thumbprintInput() ([]byte, error) {
+	var members []member
+	switch k.KeyType {
+	case "RSA":
+		if len(k.N) == 0 || len(k.E) == 0 {
+			return nil, ErrInvalidKey
+		}
+		members = []member{{"e", param(k.E)}, {"kty", k.KeyType}, {"n", param(k.N)}}
+	case "EC":
+		if k.Crv == "" || len(k.X) == 0 || len(k.Y) == 0 {
+			return nil, ErrInvalidKey
+		}
+		members = []member{{"crv", k.Crv}, {"kty", k.KeyType}, {"x", param(k.X)}, {"y", param(k.Y)}}
+	case "OKP":
+		if k.Crv == "" || len(k.X) == 0 {
+			return nil, ErrInvalidKey
+		}
+		members = []member{{"crv", k.Crv}, {"kty", k.KeyType}, {"x", param(k.X)}}
+	case "oct":
+		if len(k.K) == 0 {
+			return nil, ErrInvalidKey
+		}
+		members = []member{{"k", param(k.K)}, {"kty", k.KeyType}}
+	default:
+		return nil, ErrUnsupportedKeyType
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, m := range members {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, _ := json.Marshal(m.name)
+		val, err := json.Marshal(m.val)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(val)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// member is a member of a JSON object.
+type member struct {
+	name string
+	val  interface{}
+}
@@ -0,0 +1,41 @@
+package jwk
+
+import (
+	"crypto"
+	"encoding/json"
+	"testing"
+
+	"github.com/SermoDigital/jose"
+)
+
+func TestThumbprint(t *testing.T) {
+	var s JWKSet
+	if err := json.Unmarshal([]byte(rfcSet), &s); err != nil {
+		t.Fatal(err)
+	}
+
+	// https://tools.ietf.org/html/rfc7638#section-3.1
+	const want = "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs"
+	tp, err := s.Keys[1].Thumbprint(crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(jose.Base64Encode(tp)); got != want {
+		t.Errorf("wanted %s, got %s", want, got)
+	}
+
+	uri, err := s.Keys[1].ThumbprintURI()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uri != "urn:ietf:params:oauth:jwk-thumbprint:sha-256:"+want {
+		t.Errorf("unexpected URI %s", uri)
+	}
+
+	if _, err := s.Keys[0].Thumbprint(crypto.SHA256); err != nil {
+		t.Error(err)
+	}
+	if _, err := (&JWK{KeyType: "RSA"}).Thumbprint(crypto.SHA256); err != ErrInvalidKey {
+		t.Errorf("wanted %v, got %v", ErrInvalidKey, err)
+	}
+}
@@ -0,0 +1,78 @@
+package jwk
+
+import (
+	"github.com/SermoDigital/jose/crypto"
+	"github.com/SermoDigital/jose/jws"
+)
+
+// Verify verifies j with the keys in s identified by j's "kid"
+// Protected Header parameter. If j doesn't have a "kid" parameter, each
+// key in s is tried in turn until one verifies j.
+//
+// Keys which can't be used to verify j are skipped: those whose "use"
+// parameter is set to anything but "sig", whose "key_ops" parameter
+// doesn't include "verify", or whose "alg" parameter doesn't match j's
+// "alg" Protected Header parameter.
+//
+// methods and o are passed to j's VerifyMulti method. Symmetric ("oct")
+// keys are passed as their raw bytes, others as their public key.
+//
+// ErrKeyNotFound is returned if s has no usable keys with j's "kid"
+// parameter, or if j doesn't have one and s has no usable keys.
+func (s *JWKSet) Verify(j jws.JWS, methods []crypto.SigningMethod, o *jws.SigningOpts) error {
+	kid, hasKID := j.KeyID()
+	alg, _ := j.Algorithm()
+
+	err := ErrKeyNotFound
+	for i := range s.Keys {
+		k := &s.Keys[i]
+		if hasKID && k.KeyID != kid || !k.canVerify(alg) {
+			continue
+		}
+		key, kerr := k.verificationKey()
+		if kerr != nil {
+			if hasKID {
+				err = kerr
+			}
+			continue
+		}
+		if err = j.VerifyMulti([]interface{}{key}, methods, o); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// canVerify returns true if k may be used to verify signatures created
+// with the algorithm alg, per
+// https://tools.ietf.org/html/rfc7517#section-4
+func (k *JWK) canVerify(alg string) bool {
+	if k.Use != "" && k.Use != "sig" {
+		return false
+	}
+	if k.KeyOps != nil && !contains(k.KeyOps, "verify") {
+		return false
+	}
+	return k.Algorithm == "" || k.Algorithm == alg
+}
+
+func contains(a []string, s string) bool {
+	for _, v := range a {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// verificationKey returns the key used to verify signatures created by
+// k.
+func (k *JWK) verificationKey() (interface{}, error) {
+	if k.KeyType == "oct" {
+		if len(k.K) == 0 {
+			return nil, ErrInvalidKey
+		}
+		return k.K, nil
+	}
+	return k.PublicKey()
+}
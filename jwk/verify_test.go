@@ -0,0 +1,116 @@
+package jwk
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/SermoDigital/jose/crypto"
+	"github.com/SermoDigital/jose/jws"
+)
+
+func TestJWKSetVerify(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rk, err := NewJWK(&priv.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rk.KeyID = "rsa"
+	hk, err := NewJWK([]byte("0123456789abcdef0123456789abcdef"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	hk.KeyID = "hmac"
+	set := JWKSet{Keys: []JWK{*hk, *rk}}
+	methods := []crypto.SigningMethod{crypto.SigningMethodRS256}
+
+	sign := func(kid string) jws.JWS {
+		j := jws.New(map[string]interface{}{"sub": "test"}, crypto.SigningMethodRS256)
+		if kid != "" {
+			j.SetKeyID(kid)
+		}
+		b, err := j.Compact(priv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		j, err = jws.ParseCompact(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return j
+	}
+
+	if err := set.Verify(sign("rsa"), methods, nil); err != nil {
+		t.Errorf("with kid: %v", err)
+	}
+	if err := set.Verify(sign(""), methods, nil); err != nil {
+		t.Errorf("without kid: %v", err)
+	}
+	if err := set.Verify(sign("missing"), methods, nil); err != ErrKeyNotFound {
+		t.Errorf("wanted %v, got %v", ErrKeyNotFound, err)
+	}
+	if err := set.Verify(sign("hmac"), methods, nil); err == nil {
+		t.Error("verified with the wrong key")
+	}
+
+	empty := JWKSet{}
+	if err := empty.Verify(sign(""), methods, nil); err != ErrKeyNotFound {
+		t.Errorf("wanted %v, got %v", ErrKeyNotFound, err)
+	}
+}
+
+func TestJWKSetVerifySkipsUnusableKeys(t *testing.T) {
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sign := func(kid string) jws.JWS {
+		j := jws.New(map[string]interface{}{"sub": "test"}, crypto.SigningMethodRS256)
+		if kid != "" {
+			j.SetKeyID(kid)
+		}
+		b, err := j.Compact(priv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		j, err = jws.ParseCompact(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return j
+	}
+	withKID, withoutKID := sign("a"), sign("")
+	methods := []crypto.SigningMethod{crypto.SigningMethodRS256}
+
+	for _, tt := range []struct {
+		name string
+		edit func(k *JWK)
+		err  error
+	}{
+		{"no restrictions", func(k *JWK) {}, nil},
+		{`"use":"sig"`, func(k *JWK) { k.Use = "sig" }, nil},
+		{`"use":"enc"`, func(k *JWK) { k.Use = "enc" }, ErrKeyNotFound},
+		{`"key_ops":["verify"]`, func(k *JWK) { k.KeyOps = []string{"verify"} }, nil},
+		{`"key_ops":["sign"]`, func(k *JWK) { k.KeyOps = []string{"sign"} }, ErrKeyNotFound},
+		{`"alg":"RS256"`, func(k *JWK) { k.Algorithm = "RS256" }, nil},
+		{`"alg":"RS512"`, func(k *JWK) { k.Algorithm = "RS512" }, ErrKeyNotFound},
+	} {
+		k, err := NewJWK(&priv.PublicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		k.KeyID = "a"
+		tt.edit(k)
+		set := JWKSet{Keys: []JWK{*k}}
+
+		if err := set.Verify(withKID, methods, nil); err != tt.err {
+			t.Errorf("%s, with kid: wanted %v, got %v", tt.name, tt.err, err)
+		}
+		if err := set.Verify(withoutKID, methods, nil); err != tt.err {
+			t.Errorf("%s, without kid: wanted %v, got %v", tt.name, tt.err, err)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package jwk
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/base64"
+)
+
+// CertificateChain parses the JWK's "x5c" parameter per
+// https://tools.ietf.org/html/rfc7517#section-4.7
+//
+// It returns ErrCertificateMismatch if the first certificate's public
+// key doesn't match the JWK's public key. If the JWK has no "x5c"
+// parameter, CertificateChain returns a nil slice and no error.
+func (k *JWK) CertificateChain() ([]*x509.Certificate, error) {
+	if len(k.X5C) == 0 {
+		return nil, nil
+	}
+	certs := make([]*x509.Certificate, len(k.X5C))
+	for i, enc := range k.X5C {
+		der, err := base64.StdEncoding.DecodeString(enc)
+		if err != nil {
+			return nil, err
+		}
+		certs[i], err = x509.ParseCertificate(der)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pub, err := k.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	if p, ok := pub.(publicKey); !ok || !p.Equal(certs[0].PublicKey) {
+		return nil, ErrCertificateMismatch
+	}
+	return certs, nil
+}
+
+// publicKey is implemented by the standard library's public keys.
+type publicKey interface {
+	Equal(x crypto.PublicKey) bool
+}
@@ -0,0 +1,70 @@
+package jwk
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func selfSigned(t *testing.T, key *ecdsa.PrivateKey) string {
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "jwk test"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(der)
+}
+
+func TestCertificateChain(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k, err := NewJWK(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k.X5C = []string{selfSigned(t, key)}
+
+	b, err := json.Marshal(k)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var k2 JWK
+	if err := json.Unmarshal(b, &k2); err != nil {
+		t.Fatal(err)
+	}
+	certs, err := k2.CertificateChain()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(certs) != 1 || certs[0].Subject.CommonName != "jwk test" {
+		t.Fatalf("got %v", certs)
+	}
+
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k.X5C = []string{selfSigned(t, other)}
+	if _, err := k.CertificateChain(); err != ErrCertificateMismatch {
+		t.Fatalf("wanted %v, got %v", ErrCertificateMismatch, err)
+	}
+
+	k.X5C = nil
+	if certs, err := k.CertificateChain(); certs != nil || err != nil {
+		t.Fatalf("got %v, %v", certs, err)
+	}
+}
@@ -0,0 +1,88 @@
+package jws
+
+import (
+	"time"
+
+	"github.com/SermoDigital/jose"
+	"github.com/SermoDigital/jose/crypto"
+	"github.com/SermoDigital/jose/jwt"
+)
+
+// Builder constructs a compact JWT using a fluent API. For example:
+//
+//	b, err := jws.NewBuilder().
+//	    Claims(c).
+//	    Method(crypto.SigningMethodRS256).
+//	    KeyID("key-1").
+//	    ExpiresIn(15 * time.Minute).
+//	    IssuedNow().
+//	    Build(key)
+//
+// The Builder never modifies the Claims passed to it.
+type Builder struct {
+	claims    Claims
+	method    crypto.SigningMethod
+	kid       string
+	expiresIn time.Duration
+	issued    bool
+}
+
+// NewBuilder returns a new, empty Builder.
+func NewBuilder() *Builder { return &Builder{} }
+
+// Claims sets the JWT's claims.
+func (b *Builder) Claims(c Claims) *Builder {
+	b.claims = c
+	return b
+}
+
+// Method sets the crypto.SigningMethod used to sign the JWT.
+// It must be called before Build.
+func (b *Builder) Method(m crypto.SigningMethod) *Builder {
+	b.method = m
+	return b
+}
+
+// KeyID sets the "kid" Protected Header parameter.
+func (b *Builder) KeyID(kid string) *Builder {
+	b.kid = kid
+	return b
+}
+
+// ExpiresIn sets claim "exp" to d after the time Build is called.
+func (b *Builder) ExpiresIn(d time.Duration) *Builder {
+	b.expiresIn = d
+	return b
+}
+
+// IssuedNow sets claim "iat" to the time Build is called.
+func (b *Builder) IssuedNow() *Builder {
+	b.issued = true
+	return b
+}
+
+// Build signs the JWT with key and returns its compact serialization.
+// It returns ErrBuilderNoMethod if Method hasn't been called.
+func (b *Builder) Build(key interface{}) ([]byte, error) {
+	if b.method == nil {
+		return nil, ErrBuilderNoMethod
+	}
+
+	c := jwt.Claims(b.claims).DeepCopy()
+	if c == nil {
+		c = make(jwt.Claims)
+	}
+	now := jose.Now()
+	if b.expiresIn != 0 {
+		c.SetExpiration(now.Add(b.expiresIn))
+	}
+	if b.issued {
+		c.SetIssuedAt(now)
+	}
+
+	j := NewJWT(Claims(c), b.method).(*jws)
+	if b.kid != "" {
+		j.SetKeyID(b.kid)
+	}
+	return j.Serialize(key)
+}
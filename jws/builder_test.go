@@ -0,0 +1,54 @@
+package jws
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SermoDigital/jose/crypto"
+)
+
+func TestBuilder(t *testing.T) {
+	c := Claims{"sub": "1234"}
+
+	b, err := NewBuilder().
+		Claims(c).
+		Method(crypto.SigningMethodRS256).
+		KeyID("key-1").
+		ExpiresIn(15 * time.Minute).
+		IssuedNow().
+		Build(rsaPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(c) != 1 {
+		t.Errorf("claims were modified: %v", c)
+	}
+
+	j, err := ParseJWT(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := j.Validate(rsaPub, crypto.SigningMethodRS256); err != nil {
+		t.Error(err)
+	}
+	if kid, _ := j.(JWS).KeyID(); kid != "key-1" {
+		Error(t, "key-1", kid)
+	}
+
+	jc := j.Claims()
+	iat, ok1 := jc.IssuedAt()
+	exp, ok2 := jc.Expiration()
+	if !ok1 || !ok2 || exp.Sub(iat) != 15*time.Minute {
+		t.Errorf("got iat %v and exp %v", iat, exp)
+	}
+	if sub, _ := jc.Subject(); sub != "1234" {
+		Error(t, "1234", sub)
+	}
+}
+
+func TestBuilderNoMethod(t *testing.T) {
+	if _, err := NewBuilder().Claims(Claims{}).Build(rsaPriv); err != ErrBuilderNoMethod {
+		Error(t, ErrBuilderNoMethod, err)
+	}
+}
@@ -65,6 +65,13 @@ func (c *Claims) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// ValidateAt validates the Claims as of t per the claims found in
+// https://tools.ietf.org/html/rfc7519#section-4.1
+// See jwt.Claims.Validate.
+func (c Claims) ValidateAt(t time.Time, expLeeway, nbfLeeway time.Duration) error {
+	return jwt.Claims(c).Validate(t, expLeeway, nbfLeeway)
+}
+
 // Issuer retrieves claim "iss" per its type in
 // https://tools.ietf.org/html/rfc7519#section-4.1.1
 func (c Claims) Issuer() (string, bool) {
@@ -184,6 +191,18 @@ func (c Claims) SetJWTID(uniqueID string) {
 	jwt.Claims(c).SetJWTID(uniqueID)
 }
 
+// NormalizeNumericDates converts the float64 values of claims "exp",
+// "nbf", and "iat", as produced by encoding/json, into int64 values by
+// truncation. Other values are left untouched. ParseJWT calls it on the
+// JWT's claims.
+func NormalizeNumericDates(c Claims) {
+	for _, key := range [...]string{"exp", "nbf", "iat"} {
+		if f, ok := c[key].(float64); ok {
+			c[key] = int64(f)
+		}
+	}
+}
+
 var (
 	_ json.Marshaler   = (Claims)(nil)
 	_ json.Unmarshaler = (*Claims)(nil)
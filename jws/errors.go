@@ -1,6 +1,10 @@
 package jws
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/SermoDigital/jose"
+)
 
 var (
 
@@ -8,6 +12,10 @@ var (
 	// methods were called with 0 SigningMethods.
 	ErrNotEnoughMethods = errors.New("not enough methods provided")
 
+	// ErrBuilderNoMethod is returned by Builder.Build if Builder.Method
+	// hasn't been called.
+	ErrBuilderNoMethod = errors.New("Builder.Method must be called before Build")
+
 	// ErrCouldNotUnmarshal is returned when Parse's json.Unmarshaler
 	// parameter returns an error.
 	ErrCouldNotUnmarshal = errors.New("custom unmarshal failed")
@@ -18,7 +26,7 @@ var (
 
 	// ErrDuplicateHeaderParameter signals that there are duplicate parameters
 	// in the provided Headers.
-	ErrDuplicateHeaderParameter = errors.New("duplicate parameters in the JOSE Header")
+	ErrDuplicateHeaderParameter = jose.ErrDuplicateHeaderParameter
 
 	// ErrTwoEmptyHeaders is returned if both Headers are empty.
 	ErrTwoEmptyHeaders = errors.New("both headers cannot be empty")
@@ -27,6 +35,14 @@ var (
 	// the given SigningMethods.
 	ErrNotEnoughKeys = errors.New("not enough keys (for given methods)")
 
+	// ErrCannotRemoveLastSignature is returned when removing a signature
+	// would leave the JWS without any signatures.
+	ErrCannotRemoveLastSignature = errors.New("cannot remove last signature")
+
+	// ErrSignatureIndexOutOfRange is returned when a signature index
+	// doesn't refer to one of the JWS' signatures.
+	ErrSignatureIndexOutOfRange = errors.New("signature index out of range")
+
 	// ErrDidNotValidate means the given JWT did not properly validate
 	ErrDidNotValidate = errors.New("did not validate")
 
@@ -34,6 +50,10 @@ var (
 	// Header.
 	ErrNoAlgorithm = errors.New("no algorithm found")
 
+	// ErrNoneAlgorithmForbidden means the JWS uses the "none" algorithm,
+	// which hasn't been explicitly registered.
+	ErrNoneAlgorithmForbidden = errors.New(`"none" algorithm is forbidden`)
+
 	// ErrAlgorithmDoesntExist means the algorithm asked for cannot be
 	// found inside the signingMethod cache.
 	ErrAlgorithmDoesntExist = errors.New("algorithm doesn't exist")
@@ -42,6 +62,10 @@ var (
 	// different than the algorithm the caller wanted to use.
 	ErrMismatchedAlgorithms = errors.New("mismatched algorithms")
 
+	// ErrAlgorithmNotAllowed means the algorithm inside the JWS isn't
+	// one of the algorithms the caller allowed.
+	ErrAlgorithmNotAllowed = errors.New("algorithm not allowed")
+
 	// ErrCannotValidate means the JWS cannot be validated for various
 	// reasons. For example, if there aren't any signatures/payloads/headers
 	// to actually validate.
@@ -50,6 +74,10 @@ var (
 	// ErrIsNotJWT means the given JWS is not a JWT.
 	ErrIsNotJWT = errors.New("JWS is not a JWT")
 
+	// ErrTypHeaderMismatch means the JWT's "typ" header parameter isn't
+	// "JWT".
+	ErrTypHeaderMismatch = errors.New(`"typ" header parameter is not "JWT"`)
+
 	// ErrHoldsJWE means the given JWS holds a JWE inside its payload.
 	ErrHoldsJWE = errors.New("JWS holds JWE")
 
@@ -57,6 +85,22 @@ var (
 	// number of signatures.
 	ErrNotEnoughValidSignatures = errors.New("not enough valid signatures in the JWS")
 
+	// ErrDuplicateSigningMethod is returned by RegisterSigningMethod if a
+	// crypto.SigningMethod with the same algorithm is already registered.
+	ErrDuplicateSigningMethod = errors.New("cannot duplicate signing methods")
+
+	// ErrHashUnavailable is returned by RegisterSigningMethod if the
+	// crypto.SigningMethod's hash isn't linked into the binary.
+	ErrHashUnavailable = errors.New("specific hash is unavailable")
+
 	// ErrNoTokenInRequest means there's no token present inside the *http.Request.
 	ErrNoTokenInRequest = errors.New("no token present in request")
+
+	// ErrNoAuthorizationHeader means the *http.Request doesn't have an
+	// Authorization header.
+	ErrNoAuthorizationHeader = errors.New("no Authorization header present in request")
+
+	// ErrMalformedAuthorizationHeader means the *http.Request's
+	// Authorization header isn't of the form "Bearer <token>".
+	ErrMalformedAuthorizationHeader = errors.New("malformed Authorization header")
 )
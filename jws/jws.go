@@ -2,7 +2,10 @@ package jws
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"strings"
 
@@ -18,6 +21,12 @@ type JWS interface {
 	// SetPayload sets the payload with the given value.
 	SetPayload(p interface{})
 
+	// PayloadBytes returns the payload's raw, base64url-decoded bytes.
+	PayloadBytes() ([]byte, error)
+
+	// PayloadAs decodes the payload's JSON into v.
+	PayloadAs(v interface{}) error
+
 	// Protected returns the JWS' Protected Header.
 	Protected() jose.Protected
 
@@ -28,15 +37,66 @@ type JWS interface {
 	// Header returns the JWS' unprotected Header.
 	Header() jose.Header
 
+	// NumSignatures returns the number of signatures (and therefore
+	// Protected Headers) the JWS has.
+	NumSignatures() int
+
+	// RawProtected returns the base64url-encoded Protected Header as it
+	// was parsed or last serialized.
+	// i represents the index of the Protected Header. Left empty, it
+	// defaults to 0.
+	RawProtected(i ...int) []byte
+
+	// RawSignature returns the base64url-encoded signature.
+	// i represents the index of the signature. Left empty, it defaults
+	// to 0.
+	RawSignature(i ...int) []byte
+
 	// HeaderAt returns the JWS' unprotected Header.
 	// i represents the index of the unprotected Header.
 	HeaderAt(i int) jose.Header
 
+	// Algorithm returns the "alg" parameter of the JWS' Protected Header.
+	// It returns false if the parameter is missing or isn't a string.
+	Algorithm() (string, bool)
+
+	// AlgorithmAt returns the "alg" parameter of the JWS' Protected
+	// Header. i represents the index of the Protected Header.
+	AlgorithmAt(i int) (string, bool)
+
+	// KeyID returns the "kid" parameter of the JWS' Protected Header.
+	// It returns false if the parameter is missing or isn't a string.
+	KeyID() (string, bool)
+
+	// KeyIDAt returns the "kid" parameter of the JWS' Protected Header.
+	// i represents the index of the Protected Header.
+	KeyIDAt(i int) (string, bool)
+
+	// SetKeyID sets the "kid" parameter of the JWS' Protected Header.
+	SetKeyID(kid string)
+
+	// SetHeader sets the given parameter of the JWS' Protected Header.
+	// i represents the index of the Protected Header.
+	//
+	// Modifying the map returned by Protected or ProtectedAt after the
+	// JWS has been serialized won't be reflected in subsequent
+	// serializations. SetHeader (and SetKeyID) must be used instead, or
+	// DirtyHeader must be called after modifying the map.
+	SetHeader(i int, key string, val interface{})
+
+	// DirtyHeader marks the Protected Headers and unprotected Headers
+	// at the given indices as needing to be re-encoded. It must be
+	// called after modifying the maps returned by Protected, ProtectedAt,
+	// Header, or HeaderAt directly. Left empty, i defaults to 0. It
+	// returns ErrSignatureIndexOutOfRange, without marking any Headers,
+	// if an index isn't valid.
+	DirtyHeader(i ...int) error
+
 	// Verify validates the current JWS' signature as-is. Refer to
-	// ValidateMulti for more information.
+	// VerifyMulti for more information.
 	Verify(key interface{}, method crypto.SigningMethod) error
 
-	// ValidateMulti validates the current JWS' signature as-is. Since it's
+	// VerifyMulti validates the current JWS' signature as-is. Since it's
 	// meant to be called after parsing a stream of bytes into a JWS, it
 	// shouldn't do any internal parsing like the Sign, Flat, Compact, or
 	// General methods do.
@@ -50,20 +110,48 @@ type JWS interface {
 	// of the JWS.
 	VerifyCallback(fn VerifyCallback, methods []crypto.SigningMethod, o *SigningOpts) error
 
+	// VerifyCallbackCtx is like VerifyCallback, but passes ctx to fn so
+	// key lookups can be cancelled.
+	VerifyCallbackCtx(ctx context.Context, fn VerifyCallbackCtx, methods []crypto.SigningMethod, o *SigningOpts) error
+
+	// AddSignature adds a new signature to the JWS, signed with the
+	// given crypto.SigningMethod and key. Existing signatures are kept
+	// as-is, but must be re-signed if the payload has changed since they
+	// were created.
+	AddSignature(method crypto.SigningMethod, key interface{}) error
+
+	// RemoveSignature removes the signature at index i. It returns
+	// ErrSignatureIndexOutOfRange if i isn't a valid index, and
+	// ErrCannotRemoveLastSignature if the JWS only has one signature.
+	RemoveSignature(i int) error
+
 	// General serializes the JWS into its "general" form per
 	// https://tools.ietf.org/html/rfc7515#section-7.2.1
 	General(keys ...interface{}) ([]byte, error)
 
+	// GeneralTo is like General, but writes the JWS to w.
+	GeneralTo(w io.Writer, keys ...interface{}) error
+
 	// Flat serializes the JWS to its "flattened" form per
 	// https://tools.ietf.org/html/rfc7515#section-7.2.2
 	Flat(key interface{}) ([]byte, error)
 
+	// FlatTo is like Flat, but writes the JWS to w.
+	FlatTo(w io.Writer, key interface{}) error
+
 	// Compact serializes the JWS into its "compact" form per
 	// https://tools.ietf.org/html/rfc7515#section-7.1
 	Compact(key interface{}) ([]byte, error)
 
+	// CompactTo is like Compact, but writes the JWS to w.
+	CompactTo(w io.Writer, key interface{}) error
+
 	// IsJWT returns true if the JWS is a JWT.
 	IsJWT() bool
+
+	// IsExpired returns true if the JWS is a JWT whose "exp" claim has
+	// passed. It returns false if the claim isn't set.
+	IsExpired() (bool, error)
 }
 
 // jws represents a specific jws.
@@ -75,6 +163,10 @@ type jws struct {
 	sb []sigHead
 
 	isJWT bool
+
+	// headerOpts are Header Options waiting to be applied once every
+	// signature has been added. See applyOptions.
+	headerOpts []func(*jws)
 }
 
 // Payload returns the jws' payload.
@@ -82,9 +174,33 @@ func (j *jws) Payload() interface{} {
 	return j.payload.v
 }
 
-// SetPayload sets the jws' raw, unexported payload.
+// SetPayload sets the jws' raw, unexported payload. The payload is
+// re-marshaled the next time the jws is serialized.
 func (j *jws) SetPayload(val interface{}) {
 	j.payload.v = val
+	j.clean = false
+}
+
+// PayloadBytes returns the jws' raw, base64url-decoded payload.
+func (j *jws) PayloadBytes() ([]byte, error) {
+	if err := j.cache(); err != nil {
+		return nil, err
+	}
+	return jose.Base64Decode(j.plcache)
+}
+
+// PayloadAs decodes the jws' payload into v. If v implements
+// json.Unmarshaler its UnmarshalJSON method is used, otherwise the
+// payload is decoded with json.Unmarshal.
+func (j *jws) PayloadAs(v interface{}) error {
+	b, err := j.PayloadBytes()
+	if err != nil {
+		return err
+	}
+	if u, ok := v.(json.Unmarshaler); ok {
+		return u.UnmarshalJSON(b)
+	}
+	return json.Unmarshal(b, v)
 }
 
 // Protected returns the JWS' Protected Header.
@@ -110,6 +226,111 @@ func (j *jws) HeaderAt(i int) jose.Header {
 	return j.sb[i].unprotected
 }
 
+// Algorithm returns the "alg" parameter of the JWS' Protected Header.
+func (j *jws) Algorithm() (string, bool) {
+	return j.AlgorithmAt(0)
+}
+
+// AlgorithmAt returns the "alg" parameter of the JWS' Protected Header.
+// i represents the index of the Protected Header.
+func (j *jws) AlgorithmAt(i int) (string, bool) {
+	return j.protectedString(i, "alg")
+}
+
+// KeyID returns the "kid" parameter of the JWS' Protected Header.
+func (j *jws) KeyID() (string, bool) {
+	return j.KeyIDAt(0)
+}
+
+// KeyIDAt returns the "kid" parameter of the JWS' Protected Header.
+// i represents the index of the Protected Header.
+func (j *jws) KeyIDAt(i int) (string, bool) {
+	return j.protectedString(i, "kid")
+}
+
+// SetKeyID sets the "kid" parameter of the JWS' Protected Header.
+func (j *jws) SetKeyID(kid string) {
+	j.SetHeader(0, "kid", kid)
+}
+
+// SetHeader sets the given parameter of the JWS' Protected Header and
+// marks it as needing to be re-encoded.
+// i represents the index of the Protected Header.
+func (j *jws) SetHeader(i int, key string, val interface{}) {
+	j.sb[i].protected.Set(key, val)
+	j.sb[i].clean = false
+}
+
+// DirtyHeader marks the Protected Headers and unprotected Headers at
+// the given indices as needing to be re-encoded.
+// Left empty, i defaults to 0.
+func (j *jws) DirtyHeader(i ...int) error {
+	if len(i) == 0 {
+		i = []int{0}
+	}
+	for _, n := range i {
+		if n < 0 || n >= len(j.sb) {
+			return ErrSignatureIndexOutOfRange
+		}
+	}
+	for _, n := range i {
+		j.sb[n].clean = false
+	}
+	return nil
+}
+
+// NumSignatures returns the number of signatures the JWS has.
+func (j *jws) NumSignatures() int {
+	return len(j.sb)
+}
+
+// RawProtected returns the base64url-encoded Protected Header as it
+// was parsed or last serialized.
+// i represents the index of the Protected Header.
+// Left empty, it defaults to 0.
+func (j *jws) RawProtected(i ...int) []byte {
+	s, ok := j.sigHeadAt(i...)
+	if !ok {
+		return nil
+	}
+	return s.Protected
+}
+
+// RawSignature returns the base64url-encoded signature.
+// i represents the index of the signature.
+// Left empty, it defaults to 0.
+func (j *jws) RawSignature(i ...int) []byte {
+	s, ok := j.sigHeadAt(i...)
+	if !ok || s.Signature == nil {
+		return nil
+	}
+	b, _ := s.Signature.Base64()
+	return b
+}
+
+// sigHeadAt returns the sigHead at the first index in i, or at index 0
+// if i is empty. It returns false if the index is out of range.
+func (j *jws) sigHeadAt(i ...int) (*sigHead, bool) {
+	var n int
+	if len(i) > 0 {
+		n = i[0]
+	}
+	if n < 0 || n >= len(j.sb) {
+		return nil, false
+	}
+	return &j.sb[n], true
+}
+
+// protectedString retrieves key from the Protected Header at index i,
+// returning false if i is out of range or the value isn't a string.
+func (j *jws) protectedString(i int, key string) (string, bool) {
+	if i < 0 || i >= len(j.sb) {
+		return "", false
+	}
+	v, ok := j.sb[i].protected.Get(key).(string)
+	return v, ok
+}
+
 // sigHead represents the 'signatures' member of the jws' "general"
 // serialization form per
 // https://tools.ietf.org/html/rfc7515#section-7.2.1
@@ -128,7 +349,13 @@ type sigHead struct {
 	method crypto.SigningMethod
 }
 
-func (s *sigHead) unmarshal() error {
+func (s *sigHead) unmarshal(strict bool) error {
+	if strict {
+		if err := s.protected.UnmarshalJSONStrict(s.Protected); err != nil {
+			return err
+		}
+		return s.unprotected.UnmarshalJSONStrict(s.Unprotected)
+	}
 	if err := s.protected.UnmarshalJSON(s.Protected); err != nil {
 		return err
 	}
@@ -136,7 +363,11 @@ func (s *sigHead) unmarshal() error {
 }
 
 // New creates a JWS with the provided crypto.SigningMethods.
+// It panics if no crypto.SigningMethods are provided.
 func New(content interface{}, methods ...crypto.SigningMethod) JWS {
+	if len(methods) == 0 {
+		panic("jose/jws: New requires at least one SigningMethod")
+	}
 	sb := make([]sigHead, len(methods))
 	for i := range methods {
 		sb[i] = sigHead{
@@ -159,8 +390,11 @@ func (s *sigHead) assignMethod(p jose.Protected) error {
 		return ErrNoAlgorithm
 	}
 
-	sm := GetSigningMethod(alg)
-	if sm == nil {
+	sm, ok := GetSigningMethod(alg)
+	if !ok {
+		if alg == crypto.Unsecured.Alg() {
+			return ErrNoneAlgorithmForbidden
+		}
 		return ErrNoAlgorithm
 	}
 	s.method = sm
@@ -202,9 +436,9 @@ func Parse(encoded []byte, u ...json.Unmarshaler) (JWS, error) {
 	}
 
 	if g.Signatures == nil {
-		return g.parseFlat(u...)
+		return g.parseFlat(nil, u...)
 	}
-	return g.parseGeneral(u...)
+	return g.parseGeneral(nil, u...)
 }
 
 // ParseGeneral parses a jws serialized into its "general" form per
@@ -212,16 +446,34 @@ func Parse(encoded []byte, u ...json.Unmarshaler) (JWS, error) {
 // into a physical jws per
 // https://tools.ietf.org/html/rfc7515#section-5.2
 //
+// Any "crit" Protected Header parameter is rejected, since none are
+// understood. Use ParseGeneralWithOptions to accept specific ones.
+//
 // For information on the json.Unmarshaler parameter, see Parse.
 func ParseGeneral(encoded []byte, u ...json.Unmarshaler) (JWS, error) {
 	var g generic
 	if err := json.Unmarshal(encoded, &g); err != nil {
 		return nil, err
 	}
-	return g.parseGeneral(u...)
+	return g.parseGeneral(nil, u...)
+}
+
+// ParseGeneralFromString is like ParseGeneral, but accepts a string.
+func ParseGeneralFromString(s string, u ...json.Unmarshaler) (JWS, error) {
+	return ParseGeneral([]byte(s), u...)
+}
+
+// ParseGeneralWithOptions is like ParseGeneral, but uses o to control
+// how the JWS is parsed. A nil o is equivalent to ParseGeneral.
+func ParseGeneralWithOptions(encoded []byte, o *ParseOptions, u ...json.Unmarshaler) (JWS, error) {
+	var g generic
+	if err := json.Unmarshal(encoded, &g); err != nil {
+		return nil, err
+	}
+	return g.parseGeneral(o, u...)
 }
 
-func (g *generic) parseGeneral(u ...json.Unmarshaler) (JWS, error) {
+func (g *generic) parseGeneral(o *ParseOptions, u ...json.Unmarshaler) (JWS, error) {
 
 	var p payload
 	if len(u) > 0 {
@@ -233,10 +485,14 @@ func (g *generic) parseGeneral(u ...json.Unmarshaler) (JWS, error) {
 	}
 
 	for i := range g.Signatures {
-		if err := g.Signatures[i].unmarshal(); err != nil {
+		if err := g.Signatures[i].unmarshal(o.strictHeaders()); err != nil {
+			return nil, err
+		}
+		g.Signatures[i].clean = true
+		if err := checkHeaders(jose.Header(g.Signatures[i].protected), g.Signatures[i].unprotected, o.ignoreDupes()); err != nil {
 			return nil, err
 		}
-		if err := checkHeaders(jose.Header(g.Signatures[i].protected), g.Signatures[i].unprotected); err != nil {
+		if err := o.processCritical(g.Signatures[i].protected); err != nil {
 			return nil, err
 		}
 
@@ -260,16 +516,34 @@ func (g *generic) parseGeneral(u ...json.Unmarshaler) (JWS, error) {
 // into a physical jws per
 // https://tools.ietf.org/html/rfc7515#section-5.2
 //
+// Any "crit" Protected Header parameter is rejected, since none are
+// understood. Use ParseFlatWithOptions to accept specific ones.
+//
 // For information on the json.Unmarshaler parameter, see Parse.
 func ParseFlat(encoded []byte, u ...json.Unmarshaler) (JWS, error) {
 	var g generic
 	if err := json.Unmarshal(encoded, &g); err != nil {
 		return nil, err
 	}
-	return g.parseFlat(u...)
+	return g.parseFlat(nil, u...)
 }
 
-func (g *generic) parseFlat(u ...json.Unmarshaler) (JWS, error) {
+// ParseFlatFromString is like ParseFlat, but accepts a string.
+func ParseFlatFromString(s string, u ...json.Unmarshaler) (JWS, error) {
+	return ParseFlat([]byte(s), u...)
+}
+
+// ParseFlatWithOptions is like ParseFlat, but uses o to control how
+// the JWS is parsed. A nil o is equivalent to ParseFlat.
+func ParseFlatWithOptions(encoded []byte, o *ParseOptions, u ...json.Unmarshaler) (JWS, error) {
+	var g generic
+	if err := json.Unmarshal(encoded, &g); err != nil {
+		return nil, err
+	}
+	return g.parseFlat(o, u...)
+}
+
+func (g *generic) parseFlat(o *ParseOptions, u ...json.Unmarshaler) (JWS, error) {
 
 	var p payload
 	if len(u) > 0 {
@@ -280,12 +554,15 @@ func (g *generic) parseFlat(u ...json.Unmarshaler) (JWS, error) {
 		return nil, err
 	}
 
-	if err := g.sigHead.unmarshal(); err != nil {
+	if err := g.sigHead.unmarshal(o.strictHeaders()); err != nil {
 		return nil, err
 	}
 	g.sigHead.clean = true
 
-	if err := checkHeaders(jose.Header(g.sigHead.protected), g.sigHead.unprotected); err != nil {
+	if err := checkHeaders(jose.Header(g.sigHead.protected), g.sigHead.unprotected, o.ignoreDupes()); err != nil {
+		return nil, err
+	}
+	if err := o.processCritical(g.sigHead.protected); err != nil {
 		return nil, err
 	}
 
@@ -306,27 +583,78 @@ func (g *generic) parseFlat(u ...json.Unmarshaler) (JWS, error) {
 // into a physical jws per
 // https://tools.ietf.org/html/rfc7515#section-5.2
 //
+// Any "crit" Protected Header parameter is rejected, since none are
+// understood. Use ParseCompactWithOptions to accept specific ones.
+//
 // For information on the json.Unmarshaler parameter, see Parse.
 func ParseCompact(encoded []byte, u ...json.Unmarshaler) (JWS, error) {
-	return parseCompact(encoded, false, u...)
+	return parseCompact(encoded, false, nil, nil, u...)
+}
+
+// ParseCompactWithOptions is like ParseCompact, but accepts a
+// *ParseOptions. Since a compact JWS only has a Protected Header,
+// IgnoreDuplicateHeaders has no effect.
+func ParseCompactWithOptions(encoded []byte, o *ParseOptions, u ...json.Unmarshaler) (JWS, error) {
+	return parseCompact(encoded, false, nil, o, u...)
+}
+
+// ParseCompactFromString is like ParseCompact, but accepts a string.
+func ParseCompactFromString(s string, u ...json.Unmarshaler) (JWS, error) {
+	return ParseCompact([]byte(s), u...)
+}
+
+// ParseCompactFromReader is like ParseCompact, but reads the JWS from r.
+func ParseCompactFromReader(r io.Reader, u ...json.Unmarshaler) (JWS, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCompact(b, u...)
+}
+
+// ParseCompactWithAlgorithms is like ParseCompact, but returns
+// ErrAlgorithmNotAllowed if the JWS' "alg" header parameter isn't one
+// of allowed. The check happens before the rest of the JWS is parsed.
+func ParseCompactWithAlgorithms(encoded []byte, allowed []string, u ...json.Unmarshaler) (JWS, error) {
+	if len(allowed) == 0 {
+		return nil, ErrAlgorithmNotAllowed
+	}
+	return parseCompact(encoded, false, allowed, nil, u...)
 }
 
-func parseCompact(encoded []byte, jwt bool, u ...json.Unmarshaler) (*jws, error) {
+// parseCompact parses a compact JWS or JWT. If allowed is non-nil, the
+// "alg" header parameter must be one of its members. o may be nil.
+func parseCompact(encoded []byte, jwt bool, allowed []string, o *ParseOptions, u ...json.Unmarshaler) (*jws, error) {
 
 	// This section loosely follows
 	// https://tools.ietf.org/html/rfc7519#section-7.2
 	// because it's used to parse _both_ jws and JWTs.
 
+	// Tokens read from files, environment variables, etc. often have
+	// stray whitespace or a leftover "Bearer " prefix, neither of which
+	// can be part of a compact JWS.
+	encoded = StripBearerPrefix(encoded)
+
 	parts := bytes.Split(encoded, []byte{'.'})
 	if len(parts) != 3 {
 		return nil, ErrNotCompact
 	}
 
 	var p jose.Protected
-	if err := p.UnmarshalJSON(parts[0]); err != nil {
+	unmarshal := p.UnmarshalJSON
+	if o.strictHeaders() {
+		unmarshal = p.UnmarshalJSONStrict
+	}
+	if err := unmarshal(parts[0]); err != nil {
 		return nil, err
 	}
 
+	if allowed != nil {
+		if err := checkAlgorithm(p, allowed); err != nil {
+			return nil, err
+		}
+	}
+
 	s := sigHead{
 		Protected: parts[0],
 		protected: p,
@@ -337,6 +665,9 @@ func parseCompact(encoded []byte, jwt bool, u ...json.Unmarshaler) (*jws, error)
 	if err := s.assignMethod(p); err != nil {
 		return nil, err
 	}
+	if err := o.processCritical(p); err != nil {
+		return nil, err
+	}
 
 	var pl payload
 	if len(u) > 0 {
@@ -413,6 +744,16 @@ func init() {
 	}
 }
 
+// StripBearerPrefix removes a case-insensitive "Bearer " prefix from b,
+// as well as any surrounding whitespace.
+func StripBearerPrefix(b []byte) []byte {
+	b = bytes.TrimSpace(b)
+	if len(b) >= 7 && bytes.EqualFold(b[:7], []byte("bearer ")) {
+		b = bytes.TrimSpace(b[7:])
+	}
+	return b
+}
+
 func fromHeader(req *http.Request) ([]byte, bool) {
 	if ah := req.Header.Get("Authorization"); len(ah) > 7 && strings.EqualFold(ah[0:7], "BEARER ") {
 		return []byte(ah[7:]), true
@@ -462,6 +803,21 @@ func ParseFromRequest(req *http.Request, format Format, u ...json.Unmarshaler) (
 	return nil, err
 }
 
+// checkAlgorithm returns ErrAlgorithmNotAllowed if p's "alg" header
+// parameter isn't inside allowed.
+func checkAlgorithm(p jose.Protected, allowed []string) error {
+	alg, ok := p.Get("alg").(string)
+	if !ok {
+		return ErrNoAlgorithm
+	}
+	for _, a := range allowed {
+		if a == alg {
+			return nil
+		}
+	}
+	return ErrAlgorithmNotAllowed
+}
+
 // IgnoreDupes should be set to true if the internal duplicate header key check
 // should ignore duplicate Header keys instead of reporting an error when
 // duplicate Header keys are found.
@@ -471,16 +827,64 @@ func ParseFromRequest(req *http.Request, format Format, u ...json.Unmarshaler) (
 //     https://tools.ietf.org/html/rfc7515#section-5.2
 //     meaning keys that both the protected and unprotected
 //     Headers possess.
+//
+// Deprecated: IgnoreDupes is shared by every parse in the program. Use
+// ParseOptions' IgnoreDuplicateHeaders member instead.
 var IgnoreDupes bool
 
+// ParseOptions holds options for parsing JWSs.
+type ParseOptions struct {
+	// IgnoreDuplicateHeaders has the same meaning as IgnoreDupes, but
+	// only applies to the parse it's passed to.
+	IgnoreDuplicateHeaders bool
+
+	// Critical lists the "crit" Protected Header parameters the caller
+	// understands. Each Protected Header is checked with
+	// jose.ProcessCritical, so if Critical is empty, or the
+	// ParseOptions are nil, any JWS with a "crit" parameter is
+	// rejected.
+	Critical []string
+
+	// StrictHeaders rejects Headers which contain the same parameter
+	// more than once with ErrDuplicateHeaderParameter. By default, the
+	// last value is used.
+	StrictHeaders bool
+
+	_ struct{}
+}
+
+// processCritical checks p's "crit" parameter. If o is nil, no
+// parameters are understood.
+func (o *ParseOptions) processCritical(p jose.Protected) error {
+	if o == nil {
+		return jose.ProcessCritical(p, nil)
+	}
+	return jose.ProcessCritical(p, o.Critical)
+}
+
+// strictHeaders returns whether duplicate parameters within a Header
+// should be rejected.
+func (o *ParseOptions) strictHeaders() bool {
+	return o != nil && o.StrictHeaders
+}
+
+// ignoreDupes returns whether duplicate Header keys should be ignored,
+// falling back to IgnoreDupes if o is nil.
+func (o *ParseOptions) ignoreDupes() bool {
+	if o == nil {
+		return IgnoreDupes
+	}
+	return o.IgnoreDuplicateHeaders
+}
+
 // checkHeaders returns an error per the constraints described in
 // IgnoreDupes' comment.
-func checkHeaders(a, b jose.Header) error {
+func checkHeaders(a, b jose.Header, ignoreDupes bool) error {
 	if len(a)+len(b) == 0 {
 		return ErrTwoEmptyHeaders
 	}
 	for key := range a {
-		if b.Has(key) && !IgnoreDupes {
+		if b.Has(key) && !ignoreDupes {
 			return ErrDuplicateHeaderParameter
 		}
 	}
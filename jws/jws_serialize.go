@@ -3,24 +3,47 @@ package jws
 import (
 	"bytes"
 	"encoding/json"
+	"io"
+
+	"github.com/SermoDigital/jose"
+	"github.com/SermoDigital/jose/crypto"
 )
 
 // Flat serializes the JWS to its "flattened" form per
 // https://tools.ietf.org/html/rfc7515#section-7.2.2
 func (j *jws) Flat(key interface{}) ([]byte, error) {
+	v, err := j.flat(key)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// FlatTo is like Flat, but writes the JWS to w. The output is
+// followed by a newline.
+func (j *jws) FlatTo(w io.Writer, key interface{}) error {
+	v, err := j.flat(key)
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(w).Encode(v)
+}
+
+// flat signs j and returns its "flattened" form, ready to be encoded.
+func (j *jws) flat(key interface{}) (interface{}, error) {
 	if len(j.sb) < 1 {
 		return nil, ErrNotEnoughMethods
 	}
 	if err := j.sign(key); err != nil {
 		return nil, err
 	}
-	return json.Marshal(struct {
+	return struct {
 		Payload rawBase64 `json:"payload"`
 		sigHead
 	}{
 		Payload: j.plcache,
 		sigHead: j.sb[0],
-	})
+	}, nil
 }
 
 // General serializes the JWS into its "general" form per
@@ -29,17 +52,47 @@ func (j *jws) Flat(key interface{}) ([]byte, error) {
 // If only one key is passed it's used for all the provided
 // crypto.SigningMethods. Otherwise, len(keys) must equal the number
 // of crypto.SigningMethods added.
+//
+// If no keys are passed the existing signatures are used as-is, which
+// is only possible if the JWS hasn't changed since it was last signed
+// or parsed. This allows, e.g., a parsed JWS to be serialized after
+// calling AddSignature.
 func (j *jws) General(keys ...interface{}) ([]byte, error) {
-	if err := j.sign(keys...); err != nil {
+	v, err := j.general(keys...)
+	if err != nil {
 		return nil, err
 	}
-	return json.Marshal(struct {
+	return json.Marshal(v)
+}
+
+// GeneralTo is like General, but writes the JWS to w. The output is
+// followed by a newline.
+func (j *jws) GeneralTo(w io.Writer, keys ...interface{}) error {
+	v, err := j.general(keys...)
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(w).Encode(v)
+}
+
+// general signs j and returns its "general" form, ready to be encoded.
+func (j *jws) general(keys ...interface{}) (interface{}, error) {
+	var err error
+	if len(keys) == 0 {
+		err = j.isSigned()
+	} else {
+		err = j.sign(keys...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return struct {
 		Payload    rawBase64 `json:"payload"`
 		Signatures []sigHead `json:"signatures"`
 	}{
 		Payload:    j.plcache,
 		Signatures: j.sb,
-	})
+	}, nil
 }
 
 // Compact serializes the JWS into its "compact" form per
@@ -64,6 +117,33 @@ func (j *jws) Compact(key interface{}) ([]byte, error) {
 	), nil
 }
 
+// CompactTo is like Compact, but writes the JWS to w.
+func (j *jws) CompactTo(w io.Writer, key interface{}) error {
+	if len(j.sb) < 1 {
+		return ErrNotEnoughMethods
+	}
+
+	if err := j.sign(key); err != nil {
+		return err
+	}
+
+	sig, err := j.sb[0].Signature.Base64()
+	if err != nil {
+		return err
+	}
+	for i, part := range [...][]byte{j.sb[0].Protected, j.plcache, sig} {
+		if i > 0 {
+			if _, err := w.Write([]byte{'.'}); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(part); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // sign signs each index of j's sb member.
 func (j *jws) sign(keys ...interface{}) error {
 	if err := j.cache(); err != nil {
@@ -99,6 +179,67 @@ func (j *jws) sign(keys ...interface{}) error {
 	return nil
 }
 
+// AddSignature adds a new signature to the JWS, signed with the given
+// crypto.SigningMethod and key. Existing signatures are kept as-is,
+// unless the payload has changed since they were created, in which case
+// they must be re-signed before the JWS can be serialized.
+func (j *jws) AddSignature(method crypto.SigningMethod, key interface{}) error {
+	if !j.clean {
+		// The existing signatures cover the old payload.
+		for i := range j.sb {
+			j.sb[i].clean = false
+		}
+	}
+	if err := j.cache(); err != nil {
+		return err
+	}
+	s := sigHead{
+		protected: jose.Protected{
+			"alg": method.Alg(),
+		},
+		unprotected: jose.Header{},
+		method:      method,
+	}
+	if err := s.cache(); err != nil {
+		return err
+	}
+	sig, err := method.Sign(format(s.Protected, j.plcache), key)
+	if err != nil {
+		return err
+	}
+	s.Signature = sig
+	j.sb = append(j.sb, s)
+	return nil
+}
+
+// RemoveSignature removes the signature at index i. It returns
+// ErrSignatureIndexOutOfRange if i isn't a valid index, and
+// ErrCannotRemoveLastSignature if the JWS only has one signature.
+func (j *jws) RemoveSignature(i int) error {
+	if i < 0 || i >= len(j.sb) {
+		return ErrSignatureIndexOutOfRange
+	}
+	if len(j.sb) <= 1 {
+		return ErrCannotRemoveLastSignature
+	}
+	j.sb = append(j.sb[:i], j.sb[i+1:]...)
+	return nil
+}
+
+// isSigned returns ErrNotEnoughKeys unless every signature is up to date
+// with the payload and headers.
+func (j *jws) isSigned() error {
+	if !j.clean || len(j.sb) == 0 {
+		return ErrNotEnoughKeys
+	}
+	for i := range j.sb {
+		if !j.sb[i].clean {
+			return ErrNotEnoughKeys
+		}
+	}
+	return nil
+}
+
 // cache marshals the payload, but only if it's changed since the last cache.
 func (j *jws) cache() (err error) {
 	if !j.clean {
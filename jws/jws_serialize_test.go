@@ -114,3 +114,151 @@ func TestCompactIntegrity(t *testing.T) {
 		Error(t, dec, dataSerialized)
 	}
 }
+
+func TestAddSignature(t *testing.T) {
+	j := New(dataRaw, crypto.SigningMethodRS256)
+	b, err := j.General(rsaPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	j2, err := ParseGeneral(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := j2.AddSignature(crypto.SigningMethodPS384, rsaPriv); err != nil {
+		t.Fatal(err)
+	}
+	b, err = j2.General()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	j3, err := ParseGeneral(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sm := []crypto.SigningMethod{crypto.SigningMethodRS256, crypto.SigningMethodPS384}
+	if err := j3.VerifyMulti([]interface{}{rsaPub}, sm, nil); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := New(dataRaw, crypto.SigningMethodRS256).General(); err != ErrNotEnoughKeys {
+		Error(t, ErrNotEnoughKeys, err)
+	}
+}
+
+func TestAddSignatureDirtyPayload(t *testing.T) {
+	j := New(dataRaw, crypto.SigningMethodHS256)
+	if _, err := j.General(hm256); err != nil {
+		t.Fatal(err)
+	}
+	j.SetPayload(easyData)
+	if err := j.AddSignature(crypto.SigningMethodHS256, hm256); err != nil {
+		t.Fatal(err)
+	}
+
+	// The first signature covers the old payload.
+	if _, err := j.General(); err != ErrNotEnoughKeys {
+		Error(t, ErrNotEnoughKeys, err)
+	}
+
+	b, err := j.General(hm256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	j2, err := ParseGeneral(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := []interface{}{hm256, hm256}
+	sm := []crypto.SigningMethod{crypto.SigningMethodHS256, crypto.SigningMethodHS256}
+	if err := j2.VerifyMulti(keys, sm, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRemoveSignature(t *testing.T) {
+	j := New(dataRaw, crypto.SigningMethodRS256, crypto.SigningMethodPS384)
+	b, err := j.General(rsaPriv, rsaPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	j2, err := ParseGeneral(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := j2.NumSignatures(); n != 2 {
+		Error(t, 2, n)
+	}
+	for _, i := range []int{-1, 2} {
+		if err := j2.RemoveSignature(i); err != ErrSignatureIndexOutOfRange {
+			Error(t, ErrSignatureIndexOutOfRange, err)
+		}
+	}
+	if err := j2.RemoveSignature(0); err != nil {
+		t.Fatal(err)
+	}
+	if n := j2.NumSignatures(); n != 1 {
+		Error(t, 1, n)
+	}
+	if alg, _ := j2.Algorithm(); alg != crypto.SigningMethodPS384.Alg() {
+		Error(t, crypto.SigningMethodPS384.Alg(), alg)
+	}
+	if err := j2.RemoveSignature(0); err != ErrCannotRemoveLastSignature {
+		Error(t, ErrCannotRemoveLastSignature, err)
+	}
+
+	b, err = j2.General()
+	if err != nil {
+		t.Fatal(err)
+	}
+	j3, err := ParseGeneral(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := j3.Verify(rsaPub, crypto.SigningMethodPS384); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSerializeTo(t *testing.T) {
+	j := New(dataRaw, crypto.SigningMethodRS256)
+
+	var buf bytes.Buffer
+	if err := j.CompactTo(&buf, rsaPriv); err != nil {
+		t.Fatal(err)
+	}
+	b, err := j.Compact(rsaPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), b) {
+		Error(t, b, buf.Bytes())
+	}
+
+	buf.Reset()
+	if err := j.FlatTo(&buf, rsaPriv); err != nil {
+		t.Fatal(err)
+	}
+	b, err = j.Flat(rsaPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(bytes.TrimSpace(buf.Bytes()), b) {
+		Error(t, b, buf.Bytes())
+	}
+
+	buf.Reset()
+	if err := j.GeneralTo(&buf, rsaPriv); err != nil {
+		t.Fatal(err)
+	}
+	j2, err := ParseGeneral(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := j2.Verify(rsaPub, crypto.SigningMethodRS256); err != nil {
+		t.Error(err)
+	}
+}
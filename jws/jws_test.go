@@ -2,12 +2,14 @@ package jws
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"math/rand"
 	"testing"
 
+	"github.com/SermoDigital/jose"
 	"github.com/SermoDigital/jose/crypto"
 )
 
@@ -72,6 +74,170 @@ func TestParseCompact(t *testing.T) {
 	}
 }
 
+func TestPayloadBytes(t *testing.T) {
+	want, err := json.Marshal(dataRaw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	j := New(dataRaw, crypto.SigningMethodRS512)
+	got, err := j.PayloadBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		Error(t, want, got)
+	}
+
+	b, err := j.Compact(rsaPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	j2, err := ParseCompact(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err = j2.PayloadBytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		Error(t, want, got)
+	}
+}
+
+func TestPayloadAs(t *testing.T) {
+	b, err := New(dataRaw, crypto.SigningMethodRS512).Compact(rsaPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	j, err := ParseCompact(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var v struct {
+		Name  string
+		Admin bool
+	}
+	if err := j.PayloadAs(&v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Name != dataRaw.Name || v.Admin != dataRaw.Admin {
+		Error(t, dataRaw, v)
+	}
+
+	b, err = New(easyData, crypto.SigningMethodRS512).Compact(rsaPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	j, err = ParseCompact(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var k easy
+	if err := j.PayloadAs(&k); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(k, easyData) {
+		Error(t, easyData, k)
+	}
+}
+
+func TestRawProtectedAndSignature(t *testing.T) {
+	j := New(dataRaw, crypto.SigningMethodRS512)
+	if j.RawProtected() != nil || j.RawSignature() != nil {
+		t.Error("unsigned JWS should not have a raw Protected Header or signature")
+	}
+
+	b, err := j.Compact(rsaPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	j2, err := ParseCompact(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parts := bytes.Split(b, []byte{'.'})
+	if !bytes.Equal(j2.RawProtected(), parts[0]) {
+		Error(t, parts[0], j2.RawProtected())
+	}
+	if !bytes.Equal(j2.RawSignature(0), parts[2]) {
+		Error(t, parts[2], j2.RawSignature(0))
+	}
+	if j2.RawProtected(1) != nil || j2.RawSignature(1) != nil {
+		t.Error("out of range index should return nil")
+	}
+}
+
+func TestParseCompactWhitespace(t *testing.T) {
+	b, err := New(dataRaw, crypto.SigningMethodRS512).Compact(rsaPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b = append(append([]byte(" \t"), b...), "\r\n"...)
+	j, err := ParseCompact(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := j.Verify(rsaPub, crypto.SigningMethodRS512); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestStripBearerPrefix(t *testing.T) {
+	for in, want := range map[string]string{
+		"a.b.c":            "a.b.c",
+		"Bearer a.b.c":     "a.b.c",
+		" bEaReR  a.b.c\n": "a.b.c",
+		"Bearer":           "Bearer",
+		"Bearera.b.c":      "Bearera.b.c",
+	} {
+		if got := string(StripBearerPrefix([]byte(in))); got != want {
+			Error(t, want, got)
+		}
+	}
+
+	b, err := New(dataRaw, crypto.SigningMethodRS512).Compact(rsaPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseCompact(append([]byte("Bearer "), b...)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestParseFromString(t *testing.T) {
+	j := New(dataRaw, crypto.SigningMethodRS512)
+
+	b, err := j.Compact(rsaPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseCompactFromString(string(b)); err != nil {
+		t.Error(err)
+	}
+
+	b, err = j.Flat(rsaPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseFlatFromString(string(b)); err != nil {
+		t.Error(err)
+	}
+
+	b, err = j.General(rsaPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseGeneralFromString(string(b)); err != nil {
+		t.Error(err)
+	}
+}
+
 func TestParseCompactWithUnmarshaler(t *testing.T) {
 	j := New(easyData, crypto.SigningMethodRS512)
 	b, err := j.Compact(rsaPriv)
@@ -88,6 +254,51 @@ func TestParseCompactWithUnmarshaler(t *testing.T) {
 	}
 }
 
+func TestParseCompactWithAlgorithms(t *testing.T) {
+	j := New(easyData, crypto.SigningMethodRS512)
+	b, err := j.Compact(rsaPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	allowed := []string{"RS256", crypto.SigningMethodRS512.Alg()}
+	if _, err := ParseCompactWithAlgorithms(b, allowed); err != nil {
+		t.Error(err)
+	}
+
+	for _, allowed := range [][]string{{"HS256", "ES256"}, nil} {
+		if _, err := ParseCompactWithAlgorithms(b, allowed); err != ErrAlgorithmNotAllowed {
+			Error(t, ErrAlgorithmNotAllowed, err)
+		}
+	}
+}
+
+func TestParseNoneForbidden(t *testing.T) {
+	j := New(easyData, crypto.Unsecured)
+
+	b, err := j.Compact(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseCompact(b); err != ErrNoneAlgorithmForbidden {
+		Error(t, ErrNoneAlgorithmForbidden, err)
+	}
+
+	if b, err = j.Flat(nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseFlat(b); err != ErrNoneAlgorithmForbidden {
+		Error(t, ErrNoneAlgorithmForbidden, err)
+	}
+
+	if b, err = j.General(nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseGeneral(b); err != ErrNoneAlgorithmForbidden {
+		Error(t, ErrNoneAlgorithmForbidden, err)
+	}
+}
+
 func TestParseGeneral(t *testing.T) {
 	sm := []crypto.SigningMethod{
 		crypto.SigningMethodRS256,
@@ -114,6 +325,36 @@ func TestParseGeneral(t *testing.T) {
 	}
 }
 
+func TestVerifyMultiSubstitutedPayload(t *testing.T) {
+	sm := []crypto.SigningMethod{
+		crypto.SigningMethodRS256,
+		crypto.SigningMethodPS384,
+	}
+
+	b, err := New(dataRaw, sm...).General(rsaPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var g map[string]interface{}
+	if err := json.Unmarshal(b, &g); err != nil {
+		t.Fatal(err)
+	}
+	g["payload"] = base64.RawURLEncoding.EncodeToString([]byte(`{"admin":true}`))
+	b, err = json.Marshal(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	j, err := ParseGeneral(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := j.VerifyMulti([]interface{}{rsaPub}, sm, nil); err == nil {
+		t.Error("Should not be nil!")
+	}
+}
+
 func TestVerifyMulti(t *testing.T) {
 	sm := []crypto.SigningMethod{
 		crypto.SigningMethodRS256,
@@ -304,6 +545,35 @@ func TestVerifyMultiSigningOptsErr(t *testing.T) {
 	}
 }
 
+func TestVerifyMultiSigningOptsFactories(t *testing.T) {
+	sm := []crypto.SigningMethod{
+		crypto.SigningMethodRS256,
+		crypto.SigningMethodPS384,
+		crypto.SigningMethodPS512,
+	}
+
+	j := New(easyData, sm...)
+	b, err := j.General(rsaPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	j2, err := ParseGeneral(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys := []interface{}{rsaPub, rsaPub, rsaPub}
+	for _, o := range []*SigningOpts{AllOf(), AnyOf(), NOf(3), IndicesOf(2, 0)} {
+		if err := j2.VerifyMulti(keys, sm, o); err != nil {
+			t.Error(err)
+		}
+	}
+	if err := j2.VerifyMulti(keys, sm, NOf(4)); err == nil {
+		t.Error("Should not be nil!")
+	}
+}
+
 func TestVerify(t *testing.T) {
 	j := New(easyData, crypto.SigningMethodPS512)
 	b, err := j.Flat(rsaPriv)
@@ -363,6 +633,36 @@ func TestVerifyCallbackErr(t *testing.T) {
 	}
 }
 
+func TestVerifyCallbackCtx(t *testing.T) {
+	j := New(easyData, crypto.SigningMethodPS512)
+	b, err := j.Flat(rsaPriv)
+	if err != nil {
+		t.Error(err)
+	}
+
+	j2, err := ParseFlat(b)
+	if err != nil {
+		t.Error(err)
+	}
+
+	type key struct{}
+	cb := func(ctx context.Context, j JWS) ([]interface{}, error) {
+		return []interface{}{ctx.Value(key{})}, nil
+	}
+	methods := []crypto.SigningMethod{crypto.SigningMethodPS512}
+
+	ctx := context.WithValue(context.Background(), key{}, rsaPub)
+	if err := j2.VerifyCallbackCtx(ctx, cb, methods, nil); err != nil {
+		t.Error(err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := j2.VerifyCallbackCtx(ctx, cb, methods, nil); err != context.Canceled {
+		Error(t, context.Canceled, err)
+	}
+}
+
 func TestVerifyNoSBs(t *testing.T) {
 	j := New(easyData, crypto.SigningMethodPS512)
 	b, err := j.Flat(rsaPriv)
@@ -379,3 +679,397 @@ func TestVerifyNoSBs(t *testing.T) {
 		Error(t, ErrCannotValidate, err)
 	}
 }
+
+func TestAlgorithm(t *testing.T) {
+	j := New(easyData, crypto.SigningMethodRS256, crypto.SigningMethodPS384)
+
+	if alg, ok := j.Algorithm(); !ok || alg != "RS256" {
+		Error(t, "RS256", alg)
+	}
+	if alg, ok := j.AlgorithmAt(1); !ok || alg != "PS384" {
+		Error(t, "PS384", alg)
+	}
+	if alg, ok := j.AlgorithmAt(2); ok || alg != "" {
+		Error(t, "", alg)
+	}
+
+	j.Protected().Del("alg")
+	if alg, ok := j.Algorithm(); ok || alg != "" {
+		Error(t, "", alg)
+	}
+	j.Protected().Set("alg", 42)
+	if alg, ok := j.Algorithm(); ok || alg != "" {
+		Error(t, "", alg)
+	}
+}
+
+func TestKeyID(t *testing.T) {
+	j := New(easyData, crypto.SigningMethodRS256, crypto.SigningMethodPS384)
+	j.ProtectedAt(1).Set("kid", "key-2")
+
+	if kid, ok := j.KeyID(); ok || kid != "" {
+		Error(t, "", kid)
+	}
+	if kid, ok := j.KeyIDAt(1); !ok || kid != "key-2" {
+		Error(t, "key-2", kid)
+	}
+	if kid, ok := j.KeyIDAt(-1); ok || kid != "" {
+		Error(t, "", kid)
+	}
+}
+
+func TestSetKeyIDAfterSerialize(t *testing.T) {
+	j := New(easyData, crypto.SigningMethodRS256)
+	if _, err := j.Compact(rsaPriv); err != nil {
+		t.Fatal(err)
+	}
+
+	j.SetKeyID("key-1")
+	j.SetHeader(0, "x-custom", "value")
+
+	b, err := j.Compact(rsaPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	j2, err := ParseCompact(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kid, _ := j2.KeyID(); kid != "key-1" {
+		Error(t, "key-1", kid)
+	}
+	if v := j2.Protected().Get("x-custom"); v != "value" {
+		Error(t, "value", v)
+	}
+	if err := j2.Verify(rsaPub, crypto.SigningMethodRS256); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMultiErrorUnwrap(t *testing.T) {
+	m := MultiError{nil, ErrDidNotValidate, nil, ErrMismatchedAlgorithms}
+	var err error = &m
+
+	if !errors.Is(err, ErrMismatchedAlgorithms) {
+		t.Error("errors.Is should find ErrMismatchedAlgorithms")
+	}
+	if m.First() != ErrDidNotValidate {
+		Error(t, ErrDidNotValidate, m.First())
+	}
+	if all := m.All(); len(all) != 2 {
+		Error(t, 2, len(all))
+	}
+	if m.Len() != 2 {
+		Error(t, 2, m.Len())
+	}
+	if m.Get(1) != ErrDidNotValidate || m.Get(2) != nil || m.Get(4) != nil {
+		t.Error("Get returned an unexpected error")
+	}
+	if (&MultiError{nil}).First() != nil {
+		t.Error("First should return nil")
+	}
+}
+
+func TestVerifyMultiErrorIndices(t *testing.T) {
+	j := New(dataRaw, crypto.SigningMethodHS256, crypto.SigningMethodHS256)
+	b, err := j.General(hm256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	j2, err := ParseGeneral(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys := []interface{}{hm256, []byte("a wrong key that is at least 32 bytes long")}
+	sm := []crypto.SigningMethod{crypto.SigningMethodHS256, crypto.SigningMethodHS256}
+	err = j2.VerifyMulti(keys, sm, AllOf())
+	m, ok := err.(*MultiError)
+	if !ok {
+		t.Fatalf("wanted *MultiError, got %T", err)
+	}
+	if err := m.Get(0); err != nil {
+		t.Errorf("signature 0 should have verified, got %v", err)
+	}
+	if m.Get(1) != crypto.ErrSignatureInvalid {
+		Error(t, crypto.ErrSignatureInvalid, m.Get(1))
+	}
+	if m.Get(2) != ErrNotEnoughValidSignatures {
+		Error(t, ErrNotEnoughValidSignatures, m.Get(2))
+	}
+}
+
+func TestParseWithOptionsDuplicateHeaders(t *testing.T) {
+	j := New(dataRaw, crypto.SigningMethodRS512)
+	j.SetKeyID("a")
+
+	b, err := j.Flat(rsaPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Add an unprotected Header that duplicates the "kid" parameter.
+	var flat map[string]interface{}
+	if err := json.Unmarshal(b, &flat); err != nil {
+		t.Fatal(err)
+	}
+	flat["header"] = base64.RawURLEncoding.EncodeToString([]byte(`{"kid":"a"}`))
+	b, err = json.Marshal(flat)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ParseFlat(b); err != ErrDuplicateHeaderParameter {
+		Error(t, ErrDuplicateHeaderParameter, err)
+	}
+	if _, err := ParseFlatWithOptions(b, nil); err != ErrDuplicateHeaderParameter {
+		Error(t, ErrDuplicateHeaderParameter, err)
+	}
+	if _, err := ParseFlatWithOptions(b, &ParseOptions{IgnoreDuplicateHeaders: true}); err != nil {
+		t.Error(err)
+	}
+
+	general := map[string]interface{}{
+		"payload":    flat["payload"],
+		"signatures": []interface{}{flat},
+	}
+	delete(flat, "payload")
+	b, err = json.Marshal(general)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ParseGeneral(b); err != ErrDuplicateHeaderParameter {
+		Error(t, ErrDuplicateHeaderParameter, err)
+	}
+	if _, err := ParseGeneralWithOptions(b, &ParseOptions{IgnoreDuplicateHeaders: true}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestParseWithOptionsStrictHeaders(t *testing.T) {
+	protected := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","alg":"HS256"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"foo":"bar"}`))
+	sig, err := crypto.SigningMethodHS256.Sign([]byte(protected+"."+payload), hm256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	flat := map[string]interface{}{
+		"protected": protected,
+		"payload":   payload,
+		"signature": sig,
+	}
+	b, err := json.Marshal(flat)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ParseFlatWithOptions(b, &ParseOptions{StrictHeaders: true}); err != ErrDuplicateHeaderParameter {
+		Error(t, ErrDuplicateHeaderParameter, err)
+	}
+	j, err := ParseFlat(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := j.Verify(hm256, crypto.SigningMethodHS256); err != nil {
+		t.Error(err)
+	}
+
+	general := map[string]interface{}{
+		"payload":    payload,
+		"signatures": []interface{}{flat},
+	}
+	delete(flat, "payload")
+	b, err = json.Marshal(general)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ParseGeneralWithOptions(b, &ParseOptions{StrictHeaders: true}); err != ErrDuplicateHeaderParameter {
+		Error(t, ErrDuplicateHeaderParameter, err)
+	}
+	if _, err := ParseGeneral(b); err != nil {
+		t.Error(err)
+	}
+
+	b = []byte(protected + "." + payload + "." + string(jose.Base64Encode(sig)))
+	if _, err := ParseCompactWithOptions(b, &ParseOptions{StrictHeaders: true}); err != ErrDuplicateHeaderParameter {
+		Error(t, ErrDuplicateHeaderParameter, err)
+	}
+	if _, err := ParseCompact(b); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestParseWithOptionsCritical(t *testing.T) {
+	j := New(dataRaw, crypto.SigningMethodRS512)
+	j.SetHeader(0, "crit", []string{"exp"})
+	j.SetHeader(0, "exp", 1)
+
+	b, err := j.Flat(rsaPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := jose.ErrUnknownCriticalHeader{Parameter: "exp"}
+	if _, err := ParseFlatWithOptions(b, &ParseOptions{}); err != want {
+		Error(t, want, err)
+	}
+	if _, err := ParseFlat(b); err != want {
+		Error(t, want, err)
+	}
+	if _, err := ParseFlatWithOptions(b, &ParseOptions{Critical: []string{"exp"}}); err != nil {
+		t.Error(err)
+	}
+
+	b, err = j.General(rsaPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseGeneralWithOptions(b, &ParseOptions{}); err != want {
+		Error(t, want, err)
+	}
+	if _, err := ParseGeneral(b); err != want {
+		Error(t, want, err)
+	}
+	if _, err := ParseGeneralWithOptions(b, &ParseOptions{Critical: []string{"exp"}}); err != nil {
+		t.Error(err)
+	}
+
+	b, err = j.Compact(rsaPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseCompact(b); err != want {
+		Error(t, want, err)
+	}
+	if _, err := ParseCompactWithAlgorithms(b, []string{"RS512"}); err != want {
+		Error(t, want, err)
+	}
+	if _, err := ParseCompactWithOptions(b, &ParseOptions{Critical: []string{"exp"}}); err != nil {
+		t.Error(err)
+	}
+
+	w := NewJWT(Claims{"sub": "a"}, crypto.SigningMethodRS512)
+	w.(JWS).SetHeader(0, "crit", []string{"exp"})
+	w.(JWS).SetHeader(0, "exp", 1)
+	b, err = w.Serialize(rsaPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseJWT(b); err != want {
+		Error(t, want, err)
+	}
+}
+
+func TestSetPayloadDirtiesCache(t *testing.T) {
+	j := New(map[string]interface{}{"n": 1}, crypto.SigningMethodHS256)
+	b, err := j.Compact(hm256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	j.SetPayload(map[string]interface{}{"n": 2})
+	b2, err := j.Compact(hm256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(b, b2) {
+		t.Fatal("serialized form didn't change after SetPayload")
+	}
+
+	j2, err := ParseCompact(b2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := j2.Payload().(map[string]interface{})["n"]; n != 2.0 {
+		Error(t, 2.0, n)
+	}
+
+	// Signatures must be redone after SetPayload.
+	j.SetPayload(map[string]interface{}{"n": 3})
+	if _, err := j.General(); err != ErrNotEnoughKeys {
+		Error(t, ErrNotEnoughKeys, err)
+	}
+}
+
+func TestParseJWTKeepsPayloadCache(t *testing.T) {
+	b, err := NewJWT(Claims{"exp": 1893456000.5}, crypto.SigningMethodHS256).Serialize(hm256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := ParseJWT(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The parsed claims were normalized, but the original payload must
+	// be kept for verification and re-serialization.
+	if err := w.(JWS).Verify(hm256, crypto.SigningMethodHS256); err != nil {
+		t.Fatal(err)
+	}
+	b2, err := w.(JWS).General()
+	if err != nil {
+		t.Fatal(err)
+	}
+	j, err := ParseGeneral(b2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := j.Verify(hm256, crypto.SigningMethodHS256); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDirtyHeader(t *testing.T) {
+	j := New(easyData, crypto.SigningMethodHS256, crypto.SigningMethodHS256)
+	if _, err := j.General(hm256); err != nil {
+		t.Fatal(err)
+	}
+
+	j.ProtectedAt(0).Set("kid", "key-2")
+	j.ProtectedAt(1).Set("kid", "key-3")
+	for _, i := range []int{-1, 2} {
+		if err := j.DirtyHeader(0, i); err != ErrSignatureIndexOutOfRange {
+			Error(t, ErrSignatureIndexOutOfRange, err)
+		}
+	}
+	if err := j.DirtyHeader(0, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := j.General(); err != ErrNotEnoughKeys {
+		Error(t, ErrNotEnoughKeys, err)
+	}
+	b, err := j.General(hm256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	j2, err := ParseGeneral(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []string{"key-2", "key-3"} {
+		if kid, _ := j2.KeyIDAt(i); kid != want {
+			Error(t, want, kid)
+		}
+	}
+}
+
+func TestNewWithoutMethodsPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected New to panic without a SigningMethod")
+		}
+	}()
+	New(easyData)
+}
+
+func TestParseRejectsPaddedSignature(t *testing.T) {
+	b, err := New(easyData, crypto.SigningMethodHS256).Compact(hm256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// An HS256 signature is 32 bytes, so its padded form ends in "=".
+	padded := append(append([]byte{}, b...), '=')
+	if _, err := ParseCompact(padded); err == nil {
+		t.Error("padded signature should not be accepted")
+	}
+}
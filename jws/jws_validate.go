@@ -1,7 +1,9 @@
 package jws
 
 import (
+	"context"
 	"fmt"
+	"sort"
 
 	"github.com/SermoDigital/jose/crypto"
 )
@@ -27,6 +29,27 @@ func (j *jws) VerifyCallback(fn VerifyCallback, methods []crypto.SigningMethod,
 	return j.VerifyMulti(keys, methods, o)
 }
 
+// VerifyCallbackCtx is like VerifyCallback, but accepts a
+// context.Context which can be used to cancel key lookups, e.g. HTTP
+// requests to a JWKS endpoint.
+type VerifyCallbackCtx func(context.Context, JWS) ([]interface{}, error)
+
+// VerifyCallbackCtx is like VerifyCallback, but passes ctx to fn. It
+// returns ctx's error if ctx is done before or after fn is called.
+func (j *jws) VerifyCallbackCtx(ctx context.Context, fn VerifyCallbackCtx, methods []crypto.SigningMethod, o *SigningOpts) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	keys, err := fn(ctx, j)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return j.VerifyMulti(keys, methods, o)
+}
+
 // IsMultiError returns true if the given error is type *MultiError.
 func IsMultiError(err error) bool {
 	_, ok := err.(*MultiError)
@@ -59,13 +82,65 @@ func (m *MultiError) Error() string {
 	return fmt.Sprintf("%s (and %d other errors)", s, n-1)
 }
 
+// Unwrap returns the non-nil errors in m, allowing errors.Is and
+// errors.As to inspect each of them.
+func (m *MultiError) Unwrap() []error {
+	return m.All()
+}
+
+// First returns the first non-nil error in m, or nil if there isn't one.
+func (m *MultiError) First() error {
+	for _, err := range *m {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// All returns the non-nil errors in m.
+func (m *MultiError) All() []error {
+	var errs []error
+	for _, err := range *m {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// Len returns the number of non-nil errors in m.
+func (m *MultiError) Len() int {
+	var n int
+	for _, err := range *m {
+		if err != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// Get returns the error at index i of m, or nil if i is out of range.
+// Unlike All, the index corresponds to m's underlying slice, which
+// may contain nil errors.
+func (m *MultiError) Get(i int) error {
+	if i < 0 || i >= len(*m) {
+		return nil
+	}
+	return (*m)[i]
+}
+
 // Any means any of the JWS signatures need to verify.
-// Refer to verifyMulti for more information.
+// Refer to VerifyMulti for more information.
 const Any int = 0
 
 // VerifyMulti verifies the current JWS as-is. Since it's meant to be
 // called after parsing a stream of bytes into a JWS, it doesn't do any
 // internal parsing like the Sign, Flat, Compact, or General methods do.
+//
+// If verification fails, a *MultiError is returned whose index i holds
+// the error for signature i, or nil if it verified. If o isn't
+// satisfied, its error is stored at the extra index len(signatures).
 func (j *jws) VerifyMulti(keys []interface{}, methods []crypto.SigningMethod, o *SigningOpts) error {
 
 	// Catch a simple mistake. Parameter o is irrelevant in this scenario.
@@ -97,12 +172,15 @@ func (j *jws) VerifyMulti(keys []interface{}, methods []crypto.SigningMethod, o
 	if o == nil {
 		o = new(SigningOpts)
 	}
+	if o.all {
+		o = &SigningOpts{Number: len(j.sb), Indices: o.Indices}
+	}
 
-	var m MultiError
+	m := make(MultiError, len(j.sb))
 	for i := range j.sb {
 		err := j.sb[i].verify(j.plcache, keys[i], methods[i])
 		if err != nil {
-			m = append(m, err)
+			m[i] = err
 		} else {
 			o2.Inc()
 			if o.Needs(i) {
@@ -116,7 +194,7 @@ func (j *jws) VerifyMulti(keys []interface{}, methods []crypto.SigningMethod, o
 	if err != nil {
 		m = append(m, err)
 	}
-	if len(m) == 0 {
+	if m.Len() == 0 {
 		return nil
 	}
 	return &m
@@ -143,10 +221,36 @@ type SigningOpts struct {
 	// Indices of specific signatures which need to verify.
 	Indices []int
 	ptr     int
+	all     bool
 
 	_ struct{}
 }
 
+// AllOf returns a *SigningOpts requiring every signature to verify.
+func AllOf() *SigningOpts {
+	return &SigningOpts{all: true}
+}
+
+// NOf returns a *SigningOpts requiring at least n signatures to verify.
+func NOf(n int) *SigningOpts {
+	return &SigningOpts{Number: n}
+}
+
+// AnyOf returns a *SigningOpts requiring any of the signatures to
+// verify. It's equivalent to setting Number to Any.
+func AnyOf() *SigningOpts {
+	return &SigningOpts{Number: Any}
+}
+
+// IndicesOf returns a *SigningOpts requiring the signatures at the
+// given indices to verify.
+func IndicesOf(indices ...int) *SigningOpts {
+	x := make([]int, len(indices))
+	copy(x, indices)
+	sort.Ints(x)
+	return &SigningOpts{Indices: x}
+}
+
 // Append appends x to s' Indices member.
 func (s *SigningOpts) Append(x int) {
 	s.Indices = append(s.Indices, x)
@@ -186,7 +290,7 @@ func eq(a, b []int) bool {
 	return true
 }
 
-// Verify verifies the current JWS as-is. Refer to verifyMulti
+// Verify verifies the current JWS as-is. Refer to VerifyMulti
 // for more information.
 func (j *jws) Verify(key interface{}, method crypto.SigningMethod) error {
 	if len(j.sb) < 1 {
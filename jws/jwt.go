@@ -1,7 +1,11 @@
 package jws
 
 import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/SermoDigital/jose"
@@ -9,14 +13,18 @@ import (
 	"github.com/SermoDigital/jose/jwt"
 )
 
-// NewJWT creates a new JWT with the given claims.
-func NewJWT(claims Claims, method crypto.SigningMethod) jwt.JWT {
+// NewJWT creates a new JWT with the given claims. Per
+// https://tools.ietf.org/html/rfc7519#section-5.1 its "typ" Protected
+// Header parameter is set to "JWT", unless WithoutTypHeader is passed.
+// opts are applied after the JWT is created.
+func NewJWT(claims Claims, method crypto.SigningMethod, opts ...Option) jwt.JWT {
 	j, ok := New(claims, method).(*jws)
 	if !ok {
 		panic("jws.NewJWT: runtime panic: New(...).(*jws) != true")
 	}
 	j.sb[0].protected.Set("typ", "JWT")
 	j.isJWT = true
+	applyOptions(j, opts)
 	return j
 }
 
@@ -38,6 +46,23 @@ func (j *jws) Claims() jwt.Claims {
 	return nil
 }
 
+// IsExpired returns true if the JWT's "exp" claim is set and the
+// current time is at or past it. It returns ErrIsNotJWT if the JWS
+// isn't a JWT, and jwt.ErrInvalidEXPClaim if "exp" isn't numeric.
+func (j *jws) IsExpired() (bool, error) {
+	if !j.isJWT {
+		return false, ErrIsNotJWT
+	}
+	c := j.Claims()
+	if !c.Has("exp") {
+		return false, nil
+	}
+	if _, ok := c.Expiration(); !ok {
+		return false, jwt.ErrInvalidEXPClaim
+	}
+	return c.IsExpired(), nil
+}
+
 // ParseJWTFromRequest tries to find the JWT in an http.Request.
 // This method will call ParseMultipartForm if there's no token in the header.
 func ParseJWTFromRequest(req *http.Request) (jwt.JWT, error) {
@@ -50,12 +75,39 @@ func ParseJWTFromRequest(req *http.Request) (jwt.JWT, error) {
 	return nil, ErrNoTokenInRequest
 }
 
+// ParseJWTFromHeader parses the JWT inside an http.Request's
+// Authorization header. Unlike ParseJWTFromRequest, it doesn't fall
+// back to the request's form, and returns ErrNoAuthorizationHeader or
+// ErrMalformedAuthorizationHeader if a Bearer token cannot be found.
+func ParseJWTFromHeader(req *http.Request) (jwt.JWT, error) {
+	if req.Header.Get("Authorization") == "" {
+		return nil, ErrNoAuthorizationHeader
+	}
+	b, ok := fromHeader(req)
+	if !ok {
+		return nil, ErrMalformedAuthorizationHeader
+	}
+	return ParseJWT(b)
+}
+
+// ParseJWTFromCookie parses the JWT stored inside the named cookie of
+// an http.Request. It returns http.ErrNoCookie if the cookie isn't
+// present.
+func ParseJWTFromCookie(req *http.Request, name string) (jwt.JWT, error) {
+	c, err := req.Cookie(name)
+	if err != nil {
+		return nil, err
+	}
+	return ParseJWT([]byte(c.Value))
+}
+
 // ParseJWT parses a serialized jwt.JWT into a physical jwt.JWT.
 // If its payload isn't a set of claims (or able to be coerced into
 // a set of claims) it'll return an error stating the
-// JWT isn't a JWT.
+// JWT isn't a JWT. Like ParseCompact, it rejects any "crit" Protected
+// Header parameter.
 func ParseJWT(encoded []byte) (jwt.JWT, error) {
-	t, err := parseCompact(encoded, true)
+	t, err := parseCompact(encoded, true, nil, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -63,10 +115,78 @@ func ParseJWT(encoded []byte) (jwt.JWT, error) {
 	if !ok {
 		return nil, ErrIsNotJWT
 	}
-	t.SetPayload(Claims(c))
+	NormalizeNumericDates(Claims(c))
+	// Not SetPayload: the claims are decoded from t.plcache, so the
+	// cache is still valid.
+	t.payload.v = Claims(c)
+	return t, nil
+}
+
+// ParseJWTStrict is like ParseJWT, but returns ErrTypHeaderMismatch if
+// the JWT's "typ" Protected Header parameter is present and isn't
+// "JWT", compared case-insensitively. This prevents other kinds of
+// tokens, e.g. "at+jwt", from being accepted as plain JWTs.
+func ParseJWTStrict(encoded []byte) (jwt.JWT, error) {
+	t, err := ParseJWT(encoded)
+	if err != nil {
+		return nil, err
+	}
+	p := t.(*jws).Protected()
+	if p.Has("typ") {
+		if typ, ok := p.Get("typ").(string); !ok || !strings.EqualFold(typ, "JWT") {
+			return nil, ErrTypHeaderMismatch
+		}
+	}
 	return t, nil
 }
 
+// ParseJWTFromString is like ParseJWT, but accepts a string.
+func ParseJWTFromString(s string) (jwt.JWT, error) {
+	return ParseJWT([]byte(s))
+}
+
+// ParseJWTFromReader is like ParseJWT, but reads the JWT from r.
+func ParseJWTFromReader(r io.Reader) (jwt.JWT, error) {
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return ParseJWT(b)
+}
+
+// ParseJWTWithUnmarshaler is like ParseJWT, but it also passes the
+// decoded payload to u, allowing the caller to decode the claims into
+// a custom type without parsing the JWT a second time. The returned
+// jwt.JWT's Claims are populated as usual.
+func ParseJWTWithUnmarshaler(encoded []byte, u json.Unmarshaler) (jwt.JWT, error) {
+	if u == nil {
+		return ParseJWT(encoded)
+	}
+	t, err := parseCompact(encoded, true, nil, nil, u)
+	if err != nil {
+		return nil, err
+	}
+	var c Claims
+	if err := c.UnmarshalJSON(t.plcache); err != nil || c == nil {
+		return nil, ErrIsNotJWT
+	}
+	NormalizeNumericDates(c)
+	t.payload.v = c
+	return t, nil
+}
+
+// ParseAndVerifyJWT parses encoded with ParseJWT and validates it with
+// the JWT's Validate method. If validation fails, the parsed JWT is
+// returned along with the error so its claims can be inspected, e.g.
+// for audit logging. It must not be trusted.
+func ParseAndVerifyJWT(encoded []byte, key interface{}, method crypto.SigningMethod, v ...*jwt.Validator) (jwt.JWT, error) {
+	t, err := ParseJWT(encoded)
+	if err != nil {
+		return nil, err
+	}
+	return t, t.Validate(key, method, v...)
+}
+
 // IsJWT returns true if the JWS is a JWT.
 func (j *jws) IsJWT() bool {
 	return j.isJWT
@@ -86,7 +206,11 @@ func (j *jws) Validate(key interface{}, m crypto.SigningMethod, v ...*jwt.Valida
 			if err := v1.Validate(j); err != nil {
 				return err
 			}
-			return jwt.Claims(c).Validate(jose.Now(), v1.EXP, v1.NBF)
+			now := jose.Now
+			if v1.Clock != nil {
+				now = v1.Clock
+			}
+			return jwt.Claims(c).Validate(now(), v1.EXP, v1.NBF)
 		}
 	}
 	return ErrIsNotJWT
@@ -103,6 +227,9 @@ func Conv(fn func(Claims) error) jwt.ValidateFunc {
 }
 
 // NewValidator returns a jwt.Validator.
+//
+// The returned *jwt.Validator isn't modified during validation, so it
+// can be created once and shared between handlers and goroutines.
 func NewValidator(c Claims, exp, nbf time.Duration, fn func(Claims) error) *jwt.Validator {
 	return &jwt.Validator{
 		Expected: jwt.Claims(c),
@@ -1,12 +1,15 @@
 package jws
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"testing"
 	"time"
 
 	"github.com/SermoDigital/jose/crypto"
+	"github.com/SermoDigital/jose/jwt"
 )
 
 var claims = Claims{
@@ -55,6 +58,65 @@ func TestBasicJWT(t *testing.T) {
 	}
 }
 
+func TestParseJWTFromString(t *testing.T) {
+	b, err := NewJWT(claims, crypto.SigningMethodRS512).Serialize(rsaPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := ParseJWTFromString(string(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Claims().Get("name") != "Eric" {
+		Error(t, claims, w.Claims())
+	}
+	if err := w.Validate(rsaPub, crypto.SigningMethodRS512); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestParseJWTFromReader(t *testing.T) {
+	b, err := NewJWT(claims, crypto.SigningMethodRS512).Serialize(rsaPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := ParseJWTFromReader(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Validate(rsaPub, crypto.SigningMethodRS512); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := ParseCompactFromReader(bytes.NewReader(b)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestParseJWTStrict(t *testing.T) {
+	for typ, want := range map[interface{}]error{
+		nil:      nil,
+		"JWT":    nil,
+		"jwt":    nil,
+		"at+jwt": ErrTypHeaderMismatch,
+		1:        ErrTypHeaderMismatch,
+	} {
+		j := NewJWT(claims, crypto.SigningMethodRS512, WithoutTypHeader())
+		if typ != nil {
+			j.(JWS).SetHeader(0, "typ", typ)
+		}
+		b, err := j.Serialize(rsaPriv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ParseJWTStrict(b); err != want {
+			Error(t, want, err)
+		}
+	}
+}
+
 func TestJWTValidator(t *testing.T) {
 	j := NewJWT(claims, crypto.SigningMethodRS512)
 	j.Claims().SetIssuer("example.com")
@@ -90,6 +152,153 @@ func TestJWTValidator(t *testing.T) {
 	}
 }
 
+func TestJWTValidatorReuse(t *testing.T) {
+	v := NewValidator(Claims{"iss": "example.com"}, 0, 0, nil)
+
+	for _, iss := range []string{"example.com", "example.org", "example.com"} {
+		c := Claims{}
+		c.SetIssuer(iss)
+		b, err := NewJWT(c, crypto.SigningMethodRS512).Serialize(rsaPriv)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w, err := ParseJWT(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = w.Validate(rsaPub, crypto.SigningMethodRS512, v)
+		if iss == "example.com" && err != nil {
+			t.Error(err)
+		}
+		if iss != "example.com" && !errors.Is(err, jwt.ErrInvalidISSClaim) {
+			Error(t, jwt.ErrInvalidISSClaim, err)
+		}
+	}
+}
+
+func TestJWTValidatorAudience(t *testing.T) {
+	for _, aud := range [][]string{{"api.example.com"}, {"example.com", "api.example.com"}} {
+		c := Claims{}
+		c.SetAudience(aud...)
+
+		b, err := NewJWT(c, crypto.SigningMethodHS256).Serialize(hm256)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w, err := ParseJWT(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		v := &jwt.Validator{}
+		v.SetAudience("api.example.com")
+		if err := w.Validate(hm256, crypto.SigningMethodHS256, v); err != nil {
+			t.Errorf("%v: %v", aud, err)
+		}
+
+		v.SetAudience("other.example.com")
+		if err := w.Validate(hm256, crypto.SigningMethodHS256, v); !errors.Is(err, jwt.ErrInvalidAUDClaim) {
+			Error(t, jwt.ErrInvalidAUDClaim, err)
+		}
+	}
+}
+
+func TestJWTValidatorIssuer(t *testing.T) {
+	c := Claims{}
+	c.SetIssuer("example.com")
+
+	b, err := NewJWT(c, crypto.SigningMethodHS256).Serialize(hm256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := ParseJWT(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v := &jwt.Validator{}
+	v.SetIssuer("example.com")
+	if err := w.Validate(hm256, crypto.SigningMethodHS256, v); err != nil {
+		t.Error(err)
+	}
+
+	v.SetIssuer("evil.com")
+	err = w.Validate(hm256, crypto.SigningMethodHS256, v)
+	if !errors.Is(err, jwt.ErrInvalidISSClaim) {
+		Error(t, jwt.ErrInvalidISSClaim, err)
+	}
+	var e jwt.ErrInvalidClaimValue
+	if !errors.As(err, &e) {
+		ErrorTypes(t, jwt.ErrInvalidClaimValue{}, err)
+	}
+	if e.Claim != "iss" || e.Expected != "evil.com" || e.Got != "example.com" {
+		t.Errorf("got %+v", e)
+	}
+}
+
+func TestJWTValidatorRequiredClaims(t *testing.T) {
+	c := Claims{}
+	c.SetSubject("1234")
+
+	b, err := NewJWT(c, crypto.SigningMethodHS256).Serialize(hm256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := ParseJWT(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v := &jwt.Validator{RequiredClaims: []string{"sub"}}
+	if err := w.Validate(hm256, crypto.SigningMethodHS256, v); err != nil {
+		t.Error(err)
+	}
+
+	v.RequiredClaims = append(v.RequiredClaims, "tenant_id")
+	err = w.Validate(hm256, crypto.SigningMethodHS256, v)
+	var e jwt.ErrMissingClaim
+	if !errors.As(err, &e) {
+		ErrorTypes(t, jwt.ErrMissingClaim{}, err)
+	}
+	if e.Claim != "tenant_id" {
+		Error(t, "tenant_id", e.Claim)
+	}
+}
+
+type typedClaims struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+func (c *typedClaims) UnmarshalJSON(b []byte) error {
+	type plain typedClaims
+	return json.Unmarshal(b, (*plain)(c))
+}
+
+func TestParseJWTWithUnmarshaler(t *testing.T) {
+	b, err := NewJWT(claims, crypto.SigningMethodRS512).Serialize(rsaPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tc typedClaims
+	w, err := ParseJWTWithUnmarshaler(b, &tc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tc.Name != "Eric" || len(tc.Scopes) != 3 {
+		t.Errorf("got %+v", tc)
+	}
+	if w.Claims().Get("name") != "Eric" {
+		Error(t, "Eric", w.Claims().Get("name"))
+	}
+	if err := w.Validate(rsaPub, crypto.SigningMethodRS512); err != nil {
+		t.Error(err)
+	}
+}
+
 func TestFromHeader(t *testing.T) {
 	header := http.Header{}
 	req := &http.Request{
@@ -135,3 +344,195 @@ func TestFromHeader(t *testing.T) {
 		t.Errorf("fromHeader should return the value set as token in the Auhorization header")
 	}
 }
+
+func TestParseJWTFromHeader(t *testing.T) {
+	b, err := NewJWT(claims, crypto.SigningMethodRS512).Serialize(rsaPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	header := http.Header{}
+	req := &http.Request{
+		Header: header,
+	}
+
+	if _, err := ParseJWTFromHeader(req); err != ErrNoAuthorizationHeader {
+		Error(t, ErrNoAuthorizationHeader, err)
+	}
+
+	header.Set("Authorization", "Basic "+string(b))
+	if _, err := ParseJWTFromHeader(req); err != ErrMalformedAuthorizationHeader {
+		Error(t, ErrMalformedAuthorizationHeader, err)
+	}
+
+	header.Set("Authorization", "bearer "+string(b))
+	w, err := ParseJWTFromHeader(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Validate(rsaPub, crypto.SigningMethodRS512); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestParseJWTFromCookie(t *testing.T) {
+	b, err := NewJWT(claims, crypto.SigningMethodRS512).Serialize(rsaPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := &http.Request{
+		Header: http.Header{},
+	}
+	if _, err := ParseJWTFromCookie(req, "token"); err != http.ErrNoCookie {
+		Error(t, http.ErrNoCookie, err)
+	}
+
+	req.AddCookie(&http.Cookie{Name: "token", Value: string(b)})
+	w, err := ParseJWTFromCookie(req, "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Validate(rsaPub, crypto.SigningMethodRS512); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestJWSIsExpired(t *testing.T) {
+	c := Claims{}
+	w := NewJWT(c, crypto.SigningMethodRS512).(JWS)
+	if exp, err := w.IsExpired(); exp || err != nil {
+		t.Errorf("IsExpired() = %v, %v, wanted false, nil", exp, err)
+	}
+
+	c.SetExpiration(time.Now().Add(-time.Hour))
+	if exp, err := w.IsExpired(); !exp || err != nil {
+		t.Errorf("IsExpired() = %v, %v, wanted true, nil", exp, err)
+	}
+
+	c.SetExpiration(time.Now().Add(time.Hour))
+	if exp, err := w.IsExpired(); exp || err != nil {
+		t.Errorf("IsExpired() = %v, %v, wanted false, nil", exp, err)
+	}
+
+	c.Set("exp", "tomorrow")
+	if _, err := w.IsExpired(); err != jwt.ErrInvalidEXPClaim {
+		Error(t, jwt.ErrInvalidEXPClaim, err)
+	}
+
+	if _, err := New(dataRaw, crypto.SigningMethodRS512).IsExpired(); err != ErrIsNotJWT {
+		Error(t, ErrIsNotJWT, err)
+	}
+}
+
+func TestJWTValidatorClock(t *testing.T) {
+	exp := time.Date(2016, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	c := Claims{}
+	c.SetExpiration(exp)
+	b, err := NewJWT(c, crypto.SigningMethodRS512).Serialize(rsaPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := ParseJWT(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v := &jwt.Validator{
+		Clock: func() time.Time { return exp.Add(-time.Minute) },
+	}
+	if err := w.Validate(rsaPub, crypto.SigningMethodRS512, v); err != nil {
+		t.Error(err)
+	}
+
+	v.Clock = func() time.Time { return exp.Add(time.Minute) }
+	if err := w.Validate(rsaPub, crypto.SigningMethodRS512, v); err != jwt.ErrTokenIsExpired {
+		Error(t, jwt.ErrTokenIsExpired, err)
+	}
+}
+
+func TestNormalizeNumericDates(t *testing.T) {
+	c := Claims{"exp": 1.5e9 + 0.9, "nbf": int64(10), "iat": "now", "n": 1.5}
+	NormalizeNumericDates(c)
+	if c["exp"] != int64(1.5e9) || c["nbf"] != int64(10) || c["iat"] != "now" || c["n"] != 1.5 {
+		t.Errorf("got %v", c)
+	}
+
+	exp := time.Unix(1893456000, 0)
+	j := NewJWT(Claims{"exp": exp.Unix()}, crypto.SigningMethodHS256)
+	b, err := j.Serialize(hm256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := ParseJWT(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := w.Claims().Get("exp"); v != exp.Unix() {
+		t.Errorf("got %#v, wanted %#v", v, exp.Unix())
+	}
+
+	var tc typedClaims
+	w, err = ParseJWTWithUnmarshaler(b, &tc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := w.Claims().Get("exp"); v != exp.Unix() {
+		t.Errorf("got %#v, wanted %#v", v, exp.Unix())
+	}
+}
+
+func TestClaimsValidateAt(t *testing.T) {
+	now := time.Unix(1500000000, 0)
+	c := Claims{}
+	c.SetExpiration(now)
+	c.SetNotBefore(now.Add(-time.Hour))
+
+	if err := c.ValidateAt(now.Add(-time.Minute), 0, 0); err != nil {
+		t.Error(err)
+	}
+	if err := c.ValidateAt(now.Add(time.Minute), 0, 0); err != jwt.ErrTokenIsExpired {
+		Error(t, jwt.ErrTokenIsExpired, err)
+	}
+	if err := c.ValidateAt(now.Add(time.Minute), 2*time.Minute, 0); err != nil {
+		t.Error(err)
+	}
+	if err := c.ValidateAt(now.Add(-2*time.Hour), 0, 0); err != jwt.ErrTokenNotYetValid {
+		Error(t, jwt.ErrTokenNotYetValid, err)
+	}
+}
+
+func TestParseAndVerifyJWT(t *testing.T) {
+	c := Claims{}
+	c.SetIssuer("example.com")
+	b, err := NewJWT(c, crypto.SigningMethodHS256).Serialize(hm256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := ParseAndVerifyJWT(b, hm256, crypto.SigningMethodHS256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if iss, _ := w.Claims().Issuer(); iss != "example.com" {
+		Error(t, "example.com", iss)
+	}
+
+	v := &jwt.Validator{}
+	v.SetIssuer("evil.com")
+	w, err = ParseAndVerifyJWT(b, hm256, crypto.SigningMethodHS256, v)
+	if !errors.Is(err, jwt.ErrInvalidISSClaim) {
+		Error(t, jwt.ErrInvalidISSClaim, err)
+	}
+	if w == nil {
+		t.Fatal("wanted the parsed JWT on validation failure")
+	}
+
+	if _, err := ParseAndVerifyJWT(b, []byte("wrong key wrong key wrong key 32"), crypto.SigningMethodHS256); err == nil {
+		t.Error("wanted an error for the wrong key")
+	}
+	if w, err := ParseAndVerifyJWT([]byte("garbage"), hm256, crypto.SigningMethodHS256); w != nil || err == nil {
+		t.Errorf("got %v, %v", w, err)
+	}
+}
@@ -0,0 +1,96 @@
+package jws
+
+import (
+	"github.com/SermoDigital/jose"
+	"github.com/SermoDigital/jose/crypto"
+)
+
+// Option configures a JWS created by NewWithOptions.
+type Option func(*jws)
+
+// NewWithOptions creates a JWS with the provided content, configured
+// by opts. Header options apply to every signature, regardless of
+// where they appear relative to WithSigningMethod. Like New, it panics
+// if no WithSigningMethod option is provided.
+func NewWithOptions(content interface{}, opts ...Option) JWS {
+	j := &jws{payload: &payload{v: content}}
+	applyOptions(j, opts)
+	if len(j.sb) == 0 {
+		panic("jose/jws: NewWithOptions requires at least one WithSigningMethod option")
+	}
+	return j
+}
+
+// applyOptions applies opts to j. Header options are deferred until
+// the other options have run, so they apply to every signature, and
+// are then applied in the order they were given.
+func applyOptions(j *jws, opts []Option) {
+	for _, opt := range opts {
+		opt(j)
+	}
+	for _, fn := range j.headerOpts {
+		fn(j)
+	}
+	j.headerOpts = nil
+}
+
+// WithSigningMethod adds a signature using the given
+// crypto.SigningMethod.
+func WithSigningMethod(m crypto.SigningMethod) Option {
+	return func(j *jws) {
+		j.sb = append(j.sb, sigHead{
+			protected: jose.Protected{
+				"alg": m.Alg(),
+			},
+			unprotected: jose.Header{},
+			method:      m,
+		})
+	}
+}
+
+// WithHeader sets the given Protected Header parameter for every
+// signature.
+func WithHeader(key string, val interface{}) Option {
+	return func(j *jws) {
+		j.headerOpts = append(j.headerOpts, func(j *jws) {
+			for i := range j.sb {
+				j.SetHeader(i, key, val)
+			}
+		})
+	}
+}
+
+// WithKeyID sets the "kid" Protected Header parameter for every
+// signature.
+func WithKeyID(kid string) Option {
+	return WithHeader("kid", kid)
+}
+
+// WithContentType sets the "cty" Protected Header parameter for every
+// signature.
+func WithContentType(cty string) Option {
+	return WithHeader("cty", cty)
+}
+
+// WithIsJWT marks the JWS as a JWT and sets the "typ" Protected Header
+// parameter for every signature, like NewJWT does.
+func WithIsJWT() Option {
+	return func(j *jws) {
+		WithHeader("typ", "JWT")(j)
+		j.isJWT = true
+	}
+}
+
+// WithoutTypHeader removes the "typ" Protected Header parameter from
+// every signature. It can be passed to NewJWT for peers which reject
+// the parameter.
+func WithoutTypHeader() Option {
+	return func(j *jws) {
+		j.headerOpts = append(j.headerOpts, func(j *jws) {
+			for i := range j.sb {
+				delete(j.sb[i].protected, "typ")
+				j.sb[i].clean = false
+			}
+		})
+	}
+}
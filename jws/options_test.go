@@ -0,0 +1,104 @@
+package jws
+
+import (
+	"testing"
+
+	"github.com/SermoDigital/jose/crypto"
+)
+
+func TestNewWithOptions(t *testing.T) {
+	j := NewWithOptions(claims,
+		WithSigningMethod(crypto.SigningMethodRS256),
+		WithSigningMethod(crypto.SigningMethodPS384),
+		WithKeyID("key-1"),
+		WithContentType("example"),
+		WithHeader("x-custom", 42),
+		WithIsJWT(),
+	)
+
+	if !j.IsJWT() {
+		t.Error("got false want true")
+	}
+
+	for i, alg := range []string{"RS256", "PS384"} {
+		p := j.ProtectedAt(i)
+		if v, _ := j.AlgorithmAt(i); v != alg {
+			Error(t, alg, v)
+		}
+		if v, _ := j.KeyIDAt(i); v != "key-1" {
+			Error(t, "key-1", v)
+		}
+		if v := p.Get("cty"); v != "example" {
+			Error(t, "example", v)
+		}
+		if v := p.Get("x-custom"); v != 42 {
+			Error(t, 42, v)
+		}
+		if v := p.Get("typ"); v != "JWT" {
+			Error(t, "JWT", v)
+		}
+	}
+
+	b, err := j.General(rsaPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	j2, err := ParseGeneral(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := []interface{}{rsaPub}
+	sm := []crypto.SigningMethod{crypto.SigningMethodRS256, crypto.SigningMethodPS384}
+	if err := j2.VerifyMulti(keys, sm, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestNewJWTTypHeader(t *testing.T) {
+	w := NewJWT(Claims{"sub": "example"}, crypto.SigningMethodRS256).(JWS)
+	if v := w.Protected().Get("typ"); v != "JWT" {
+		Error(t, "JWT", v)
+	}
+
+	w = NewJWT(Claims{"sub": "example"}, crypto.SigningMethodRS256, WithoutTypHeader()).(JWS)
+	if w.Protected().Has("typ") {
+		t.Error(`"typ" should not be set`)
+	}
+	b, err := w.Compact(rsaPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w2, err := ParseJWT(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w2.(JWS).Protected().Has("typ") {
+		t.Error(`"typ" should not be set`)
+	}
+}
+
+func TestNewWithOptionsWithoutMethodPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected NewWithOptions to panic without a SigningMethod")
+		}
+	}()
+	NewWithOptions(easyData, WithKeyID("a"))
+}
+
+func TestNewWithOptionsHeaderOrder(t *testing.T) {
+	j := NewWithOptions(claims,
+		WithKeyID("a"),
+		WithIsJWT(),
+		WithSigningMethod(crypto.SigningMethodRS256),
+		WithSigningMethod(crypto.SigningMethodPS384),
+	)
+	for i := 0; i < 2; i++ {
+		if v, _ := j.KeyIDAt(i); v != "a" {
+			Error(t, "a", v)
+		}
+		if v := j.ProtectedAt(i).Get("typ"); v != "JWT" {
+			Error(t, "JWT", v)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package jws
 
 import (
+	"sort"
 	"sync"
 
 	"github.com/SermoDigital/jose/crypto"
@@ -26,25 +27,43 @@ var (
 		crypto.SigningMethodHS384.Alg(): crypto.SigningMethodHS384,
 		crypto.SigningMethodHS512.Alg(): crypto.SigningMethodHS512,
 
-		crypto.Unsecured.Alg(): crypto.Unsecured,
+		crypto.SigningMethodEd25519ph.Alg(): crypto.SigningMethodEd25519ph,
 	}
 )
 
 // RegisterSigningMethod registers the crypto.SigningMethod in the global map.
 // This is typically done inside the caller's init function.
-func RegisterSigningMethod(sm crypto.SigningMethod) {
-	alg := sm.Alg()
-	if GetSigningMethod(alg) != nil {
-		panic("jose/jws: cannot duplicate signing methods")
-	}
-
-	if !sm.Hasher().Available() {
-		panic("jose/jws: specific hash is unavailable")
+//
+// It returns ErrDuplicateSigningMethod if a crypto.SigningMethod with the
+// same algorithm is already registered, and ErrHashUnavailable if sm's
+// hash isn't linked into the binary. Signing methods which don't hash
+// their input should return crypto.Hash(0) from Hasher, which skips the
+// check.
+//
+// The "none" algorithm (crypto.Unsecured) isn't registered by default, and
+// JWSs using it are rejected with ErrNoneAlgorithmForbidden unless it's
+// explicitly registered.
+func RegisterSigningMethod(sm crypto.SigningMethod) error {
+	if h := sm.Hasher(); h != 0 && !h.Available() {
+		return ErrHashUnavailable
 	}
 
+	alg := sm.Alg()
 	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := signingMethods[alg]; ok {
+		return ErrDuplicateSigningMethod
+	}
 	signingMethods[alg] = sm
-	mu.Unlock()
+	return nil
+}
+
+// MustRegisterSigningMethod is like RegisterSigningMethod, but panics if
+// the crypto.SigningMethod can't be registered.
+func MustRegisterSigningMethod(sm crypto.SigningMethod) {
+	if err := RegisterSigningMethod(sm); err != nil {
+		panic("jose/jws: " + err.Error())
+	}
 }
 
 // RemoveSigningMethod removes the crypto.SigningMethod from the global map.
@@ -55,9 +74,30 @@ func RemoveSigningMethod(sm crypto.SigningMethod) {
 }
 
 // GetSigningMethod retrieves a crypto.SigningMethod from the global map.
-func GetSigningMethod(alg string) (method crypto.SigningMethod) {
+// It returns false if no crypto.SigningMethod is registered for alg.
+func GetSigningMethod(alg string) (method crypto.SigningMethod, ok bool) {
 	mu.RLock()
-	method = signingMethods[alg]
+	method, ok = signingMethods[alg]
 	mu.RUnlock()
+	return method, ok
+}
+
+// GetSigningMethodByAlg is like GetSigningMethod, but returns nil if no
+// crypto.SigningMethod is registered for alg.
+func GetSigningMethodByAlg(alg string) crypto.SigningMethod {
+	method, _ := GetSigningMethod(alg)
 	return method
 }
+
+// ListSigningMethods returns the sorted algorithm names of every
+// registered crypto.SigningMethod.
+func ListSigningMethods() []string {
+	mu.RLock()
+	algs := make([]string, 0, len(signingMethods))
+	for alg := range signingMethods {
+		algs = append(algs, alg)
+	}
+	mu.RUnlock()
+	sort.Strings(algs)
+	return algs
+}
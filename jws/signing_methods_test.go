@@ -2,24 +2,12 @@ package jws
 
 import (
 	"crypto"
-	"hash"
-	"io"
+	"reflect"
 	"testing"
 
 	c "github.com/SermoDigital/jose/crypto"
 )
 
-func init() { crypto.RegisterHash(crypto.Hash(0), HH) }
-
-func HH() hash.Hash { return &ff{Writer: nil} }
-
-type ff struct{ io.Writer }
-
-func (f *ff) Sum(b []byte) []byte { return nil }
-func (f *ff) Reset()              {}
-func (f *ff) Size() int           { return -1 }
-func (f *ff) BlockSize() int      { return -1 }
-
 // MySigningMethod is the default "none" algorithm.
 var MySigningMethod = &TestSigningMethod{
 	Name: "SuperSignerAlgorithm1000",
@@ -43,13 +31,15 @@ func (m *TestSigningMethod) Alg() string         { return m.Name }
 func (m *TestSigningMethod) Sum(b []byte) []byte { return nil }
 func (m *TestSigningMethod) Hasher() crypto.Hash { return m.Hash }
 
+func (m *TestSigningMethod) ValidateKey(_ interface{}) error { return nil }
+
 // GetSigningMethod is implicitly tested inside the following two functions.
 
 func TestRegisterSigningMethod(t *testing.T) {
 
 	RegisterSigningMethod(MySigningMethod)
 
-	if GetSigningMethod("SuperSignerAlgorithm1000") == nil {
+	if _, ok := GetSigningMethod("SuperSignerAlgorithm1000"); !ok {
 		t.Error("Expected SuperSignerAlgorithm1000, got nil")
 	}
 
@@ -59,13 +49,84 @@ func TestRegisterSigningMethod(t *testing.T) {
 func TestRemoveSigningMethod(t *testing.T) {
 	RegisterSigningMethod(MySigningMethod)
 
-	if GetSigningMethod("SuperSignerAlgorithm1000") == nil {
+	if _, ok := GetSigningMethod("SuperSignerAlgorithm1000"); !ok {
 		t.Error("Expected SuperSignerAlgorithm1000, got nil")
 	}
 
 	RemoveSigningMethod(MySigningMethod)
 
-	if a := GetSigningMethod("SuperSignerAlgorithm1000"); a != nil {
+	if a, ok := GetSigningMethod("SuperSignerAlgorithm1000"); ok || a != nil {
 		t.Errorf("Expected nil, got %v", a)
 	}
 }
+
+func TestRegisterSigningMethodErrors(t *testing.T) {
+	if err := RegisterSigningMethod(MySigningMethod); err != nil {
+		t.Fatal(err)
+	}
+	defer RemoveSigningMethod(MySigningMethod)
+
+	if err := RegisterSigningMethod(MySigningMethod); err != ErrDuplicateSigningMethod {
+		Error(t, ErrDuplicateSigningMethod, err)
+	}
+
+	unavailable := &TestSigningMethod{
+		Name: "UnavailableHash",
+		Hash: crypto.MD4, // golang.org/x/crypto/md4 isn't linked in.
+	}
+	if err := RegisterSigningMethod(unavailable); err != ErrHashUnavailable {
+		Error(t, ErrHashUnavailable, err)
+	}
+	if GetSigningMethodByAlg("UnavailableHash") != nil {
+		t.Error("signing method should not have been registered")
+	}
+}
+
+func TestMustRegisterSigningMethod(t *testing.T) {
+	MustRegisterSigningMethod(MySigningMethod)
+	defer RemoveSigningMethod(MySigningMethod)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for a duplicate signing method")
+		}
+	}()
+	MustRegisterSigningMethod(MySigningMethod)
+}
+
+func TestListSigningMethods(t *testing.T) {
+	want := []string{
+		"ES256", "ES384", "ES512",
+		"Ed25519ph",
+		"HS256", "HS384", "HS512",
+		"PS256", "PS384", "PS512",
+		"RS256", "RS384", "RS512",
+	}
+	if got := ListSigningMethods(); !reflect.DeepEqual(got, want) {
+		Error(t, want, got)
+	}
+
+	RegisterSigningMethod(MySigningMethod)
+	defer RemoveSigningMethod(MySigningMethod)
+	if got := ListSigningMethods(); len(got) != len(want)+1 || got[len(got)-1] != "SuperSignerAlgorithm1000" {
+		t.Errorf("got %v", got)
+	}
+}
+
+func TestEd25519phRoundTrip(t *testing.T) {
+	pub, priv, err := c.GenerateEd25519Key()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := New(easyData, c.SigningMethodEd25519ph).Compact(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	j, err := ParseCompact(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := j.Verify(pub, c.SigningMethodEd25519ph); err != nil {
+		t.Error(err)
+	}
+}
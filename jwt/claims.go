@@ -1,7 +1,14 @@
 package jwt
 
 import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/SermoDigital/jose"
@@ -52,6 +59,220 @@ func (c Claims) Has(key string) bool {
 	return ok
 }
 
+// HasAll returns true if every one of keys exists inside the Claims.
+func (c Claims) HasAll(keys ...string) bool {
+	for _, k := range keys {
+		if !c.Has(k) {
+			return false
+		}
+	}
+	return true
+}
+
+// HasAny returns true if at least one of keys exists inside the Claims.
+func (c Claims) HasAny(keys ...string) bool {
+	for _, k := range keys {
+		if c.Has(k) {
+			return true
+		}
+	}
+	return false
+}
+
+// Merge returns a new set of Claims containing every claim from c and
+// other. If a key exists in both, the value from other wins. Neither c
+// nor other is modified.
+func (c Claims) Merge(other Claims) Claims {
+	m := make(Claims, len(c)+len(other))
+	for k, v := range c {
+		m[k] = v
+	}
+	for k, v := range other {
+		m[k] = v
+	}
+	return m
+}
+
+// MergeInPlace copies every claim from other into c, overwriting
+// existing keys without warning. If c is nil it'll be allocated.
+func (c *Claims) MergeInPlace(other Claims) {
+	if len(other) == 0 {
+		return
+	}
+	if *c == nil {
+		*c = make(Claims, len(other))
+	}
+	for k, v := range other {
+		(*c)[k] = v
+	}
+}
+
+// Subset returns a new set of Claims containing only the given keys.
+// Keys which don't exist in c are ignored. The values are not copied.
+func (c Claims) Subset(keys ...string) Claims {
+	s := make(Claims, len(keys))
+	for _, k := range keys {
+		if v, ok := c[k]; ok {
+			s[k] = v
+		}
+	}
+	return s
+}
+
+// Omit returns a new set of Claims containing every claim inside c
+// except for the given keys. The values are not copied.
+func (c Claims) Omit(keys ...string) Claims {
+	o := make(Claims, len(c))
+	for k, v := range c {
+		o[k] = v
+	}
+	for _, k := range keys {
+		delete(o, k)
+	}
+	return o
+}
+
+// FilterByPrefix returns a new set of Claims containing only the
+// claims whose keys begin with prefix. The values are not copied.
+func (c Claims) FilterByPrefix(prefix string) Claims {
+	f := make(Claims)
+	for k, v := range c {
+		if strings.HasPrefix(k, prefix) {
+			f[k] = v
+		}
+	}
+	return f
+}
+
+// DeepCopy returns a fully independent copy of c. Nested maps and
+// slices produced by encoding/json (map[string]interface{},
+// []interface{}) as well as Claims and []string values are copied
+// recursively; any other values are copied as-is.
+func (c Claims) DeepCopy() Claims {
+	if c == nil {
+		return nil
+	}
+	return Claims(deepCopyMap(c))
+}
+
+func deepCopyMap(m map[string]interface{}) map[string]interface{} {
+	cp := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		cp[k] = deepCopy(v)
+	}
+	return cp
+}
+
+func deepCopy(v interface{}) interface{} {
+	switch t := v.(type) {
+	case Claims:
+		return t.DeepCopy()
+	case map[string]interface{}:
+		if t == nil {
+			return t
+		}
+		return deepCopyMap(t)
+	case []interface{}:
+		if t == nil {
+			return t
+		}
+		cp := make([]interface{}, len(t))
+		for i := range t {
+			cp[i] = deepCopy(t[i])
+		}
+		return cp
+	case []string:
+		if t == nil {
+			return t
+		}
+		cp := make([]string, len(t))
+		copy(cp, t)
+		return cp
+	default:
+		return v
+	}
+}
+
+// Each calls fn for each claim inside c, in sorted key order. It stops
+// and returns the first non-nil error returned by fn.
+func (c Claims) Each(fn func(key string, val interface{}) error) error {
+	for _, k := range c.Keys() {
+		if err := fn(k, c[k]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Keys returns the keys of every claim inside c in sorted order.
+func (c Claims) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Pair is a single claim's key and value.
+type Pair struct {
+	Key string
+	Val interface{}
+}
+
+// SortedPairs returns every claim inside c, sorted by key.
+func (c Claims) SortedPairs() []Pair {
+	keys := c.Keys()
+	pairs := make([]Pair, len(keys))
+	for i, k := range keys {
+		pairs[i] = Pair{Key: k, Val: c[k]}
+	}
+	return pairs
+}
+
+// Diff compares c to other and returns three sets of Claims: claims
+// that only exist in other (added), claims that only exist in c
+// (removed), and claims that exist in both but whose values differ
+// (changed). Values inside changed are taken from other.
+//
+// Values are equal if they're reflect.DeepEqual or have the same JSON
+// encoding, so e.g. int64(5) and the float64(5) of a parsed JWT are
+// equal.
+func (c Claims) Diff(other Claims) (added, removed, changed Claims) {
+	added, removed, changed = make(Claims), make(Claims), make(Claims)
+	for k, v := range c {
+		v2, ok := other[k]
+		if !ok {
+			removed[k] = v
+		} else if !equalValues(v, v2) {
+			changed[k] = v2
+		}
+	}
+	for k, v := range other {
+		if _, ok := c[k]; !ok {
+			added[k] = v
+		}
+	}
+	return added, removed, changed
+}
+
+// equalValues returns true if a and b are reflect.DeepEqual or have
+// the same JSON encoding.
+func equalValues(a, b interface{}) bool {
+	if reflect.DeepEqual(a, b) {
+		return true
+	}
+	ab, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	bb, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(ab, bb)
+}
+
 // MarshalJSON implements json.Marshaler for Claims.
 func (c Claims) MarshalJSON() ([]byte, error) {
 	if c == nil || len(c) == 0 {
@@ -93,6 +314,42 @@ func (c *Claims) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// ToStruct decodes c into v, which must be a pointer, using the
+// same rules as json.Unmarshal. It's useful for accessing the claims
+// through a typed structure, e.g. immediately after parsing a JWT.
+func (c Claims) ToStruct(v interface{}) error {
+	b, err := json.Marshal(map[string]interface{}(c))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// FromStruct encodes v using the same rules as json.Marshal and stores
+// the resulting members inside c, overwriting existing keys without
+// warning. If v doesn't encode to a JSON object, e.g. because it's a
+// nil pointer, ErrNotJSONObject is returned and c is left untouched.
+func (c *Claims) FromStruct(v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if len(b) == 0 || b[0] != '{' {
+		return ErrNotJSONObject
+	}
+	var tmp map[string]interface{}
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+	if *c == nil {
+		*c = make(Claims, len(tmp))
+	}
+	for k, v := range tmp {
+		(*c)[k] = v
+	}
+	return nil
+}
+
 // Issuer retrieves claim "iss" per its type in
 // https://tools.ietf.org/html/rfc7519#section-4.1.1
 func (c Claims) Issuer() (string, bool) {
@@ -147,6 +404,24 @@ func (c Claims) Expiration() (time.Time, bool) {
 	return c.GetTime("exp")
 }
 
+// IsExpired returns true if claim "exp" is set and the current time
+// is at or past it. Unlike Validate, no leeway is applied.
+func (c Claims) IsExpired() bool {
+	exp, ok := c.Expiration()
+	return ok && jose.Now().Unix() >= exp.Unix()
+}
+
+// TimeUntilExpiry returns the duration until claim "exp" is reached.
+// The duration is negative if the claims have already expired. It
+// returns false if "exp" is not set.
+func (c Claims) TimeUntilExpiry() (time.Duration, bool) {
+	exp, ok := c.Expiration()
+	if !ok {
+		return 0, false
+	}
+	return exp.Sub(jose.Now()), true
+}
+
 // NotBefore retrieves claim "nbf" per its type in
 // https://tools.ietf.org/html/rfc7519#section-4.1.5
 func (c Claims) NotBefore() (time.Time, bool) {
@@ -166,6 +441,44 @@ func (c Claims) JWTID() (string, bool) {
 	return v, ok
 }
 
+// VerifyAudience returns true if expected is listed inside claim "aud".
+// If "aud" is absent, it returns !required.
+func (c Claims) VerifyAudience(expected string, required bool) bool {
+	if !c.Has("aud") {
+		return !required
+	}
+	aud, ok := c.Audience()
+	if !ok {
+		return false
+	}
+	for _, v := range aud {
+		if v == expected {
+			return true
+		}
+	}
+	return false
+}
+
+// VerifyIssuer returns true if claim "iss" equals expected.
+// If "iss" is absent, it returns !required.
+func (c Claims) VerifyIssuer(expected string, required bool) bool {
+	return c.verifyString("iss", expected, required)
+}
+
+// VerifySubject returns true if claim "sub" equals expected.
+// If "sub" is absent, it returns !required.
+func (c Claims) VerifySubject(expected string, required bool) bool {
+	return c.verifyString("sub", expected, required)
+}
+
+func (c Claims) verifyString(key, expected string, required bool) bool {
+	if !c.Has(key) {
+		return !required
+	}
+	v, ok := c.Get(key).(string)
+	return ok && v == expected
+}
+
 // RemoveIssuer deletes claim "iss" from c.
 func (c Claims) RemoveIssuer() { c.Del("iss") }
 
@@ -227,12 +540,52 @@ func (c Claims) SetIssuedAt(issuedAt time.Time) {
 	c.SetTime("iat", issuedAt)
 }
 
+// SetIssuedAtNow sets claim "iat" to the current time.
+func (c Claims) SetIssuedAtNow() {
+	c.SetIssuedAt(jose.Now())
+}
+
+// ExpiresIn sets claim "exp" to d from now.
+func (c Claims) ExpiresIn(d time.Duration) {
+	c.SetExpiration(jose.Now().Add(d))
+}
+
+// ValidAfter sets claim "nbf" to d from now.
+func (c Claims) ValidAfter(d time.Duration) {
+	c.SetNotBefore(jose.Now().Add(d))
+}
+
 // SetJWTID sets claim "jti" per its type in
 // https://tools.ietf.org/html/rfc7519#section-4.1.7
 func (c Claims) SetJWTID(uniqueID string) {
 	c.Set("jti", uniqueID)
 }
 
+// GenerateJTI sets claim "jti" to a random (version 4) UUID per
+// https://tools.ietf.org/html/rfc4122#section-4.4 using crypto/rand.
+func (c Claims) GenerateJTI() error {
+	var u [16]byte
+	if _, err := io.ReadFull(rand.Reader, u[:]); err != nil {
+		return err
+	}
+	u[6] = u[6]&0x0f | 0x40 // Version 4.
+	u[8] = u[8]&0x3f | 0x80 // Variant 10.
+
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+
+	c.SetJWTID(string(buf[:]))
+	return nil
+}
+
 // GetTime returns a Unix timestamp for the given key.
 //
 // It converts an int, int32, int64, uint, uint32, uint64 or float64 into a Unix
@@ -243,27 +596,18 @@ func (c Claims) SetJWTID(uniqueID string) {
 // Claims is a map[string]interface{}. However, the values may be stored directly
 // in the claims as a different type.
 func (c Claims) GetTime(key string) (time.Time, bool) {
-	switch t := c.Get(key).(type) {
-	case int:
-		return time.Unix(int64(t), 0), true
-	case int32:
-		return time.Unix(int64(t), 0), true
-	case int64:
-		return time.Unix(int64(t), 0), true
-	case uint:
-		return time.Unix(int64(t), 0), true
-	case uint32:
-		return time.Unix(int64(t), 0), true
-	case uint64:
-		return time.Unix(int64(t), 0), true
-	case float64:
-		return time.Unix(int64(t), 0), true
-	default:
+	v, ok := GetInt64(c, key)
+	if !ok {
 		return time.Time{}, false
 	}
+	return time.Unix(v, 0), true
 }
 
 // SetTime stores a UNIX time for the given key.
+//
+// NumericDate values are whole seconds per
+// https://tools.ietf.org/html/rfc7519#section-2, so any sub-second
+// precision in t is truncated.
 func (c Claims) SetTime(key string, t time.Time) {
 	c.Set(key, t.Unix())
 }
@@ -1,6 +1,9 @@
 package jwt_test
 
 import (
+	"errors"
+	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -9,6 +12,9 @@ import (
 	"github.com/SermoDigital/jose/jwt"
 )
 
+// hmacKey is long enough for crypto.SigningMethodHS256.
+var hmacKey = []byte("0123456789abcdef0123456789abcdef")
+
 func TestMultipleAudienceBug_AfterMarshal(t *testing.T) {
 
 	// Create JWS claims
@@ -16,7 +22,7 @@ func TestMultipleAudienceBug_AfterMarshal(t *testing.T) {
 	claims.SetAudience("example.com", "api.example.com")
 
 	token := jws.NewJWT(claims, crypto.SigningMethodHS256)
-	serializedToken, _ := token.Serialize([]byte("abcdef"))
+	serializedToken, _ := token.Serialize(hmacKey)
 
 	// Unmarshal JSON
 	newToken, _ := jws.ParseJWT(serializedToken)
@@ -41,7 +47,7 @@ func TestMultipleAudienceFix_AfterMarshal(t *testing.T) {
 	claims.SetAudience("example.com", "api.example.com")
 
 	token := jws.NewJWT(claims, crypto.SigningMethodHS256)
-	serializedToken, _ := token.Serialize([]byte("abcdef"))
+	serializedToken, _ := token.Serialize(hmacKey)
 
 	// Unmarshal JSON
 	newToken, _ := jws.ParseJWT(serializedToken)
@@ -67,7 +73,7 @@ func TestSingleAudienceFix_AfterMarshal(t *testing.T) {
 	claims.SetAudience("example.com")
 
 	token := jws.NewJWT(claims, crypto.SigningMethodHS256)
-	serializedToken, _ := token.Serialize([]byte("abcdef"))
+	serializedToken, _ := token.Serialize(hmacKey)
 
 	// Unmarshal JSON
 	newToken, _ := jws.ParseJWT(serializedToken)
@@ -168,7 +174,7 @@ func TestTimeValuesThroughJSON(t *testing.T) {
 
 	// serialize to JWT
 	tok := jws.NewJWT(c, crypto.SigningMethodHS256)
-	b, err := tok.Serialize([]byte("key"))
+	b, err := tok.Serialize(hmacKey)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -197,3 +203,433 @@ func TestTimeValuesThroughJSON(t *testing.T) {
 		t.Errorf("%s: got %v want %v", "exp", got, want)
 	}
 }
+
+// TestValidateAfterParseJWT verifies that numeric date claims, which
+// encoding/json decodes as float64 and ParseJWT normalizes to int64,
+// are still enforced by Validate after a round-trip through ParseJWT.
+func TestValidateAfterParseJWT(t *testing.T) {
+	now := time.Unix(time.Now().Unix(), 0)
+
+	c := jws.Claims{}
+	c.SetExpiration(now.Add(-time.Hour))
+	c.SetNotBefore(now.Add(time.Hour))
+
+	tok := jws.NewJWT(c, crypto.SigningMethodHS256)
+	b, err := tok.Serialize(hmacKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tok2, err := jws.ParseJWT(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c2 := tok2.Claims()
+
+	if _, ok := c2.Get("exp").(int64); !ok {
+		t.Fatalf("got %T want int64", c2.Get("exp"))
+	}
+	if _, ok := c2.Expiration(); !ok {
+		t.Error("exp: got false want true")
+	}
+	if _, ok := c2.NotBefore(); !ok {
+		t.Error("nbf: got false want true")
+	}
+
+	if got, want := c2.Validate(now, 0, 0), jwt.ErrTokenIsExpired; got != want {
+		t.Errorf("got %v want %v", got, want)
+	}
+
+	c2.RemoveExpiration()
+	if got, want := c2.Validate(now, 0, 0), jwt.ErrTokenNotYetValid; got != want {
+		t.Errorf("got %v want %v", got, want)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	a := jwt.Claims{"iss": "a", "sub": "a"}
+	b := jwt.Claims{"sub": "b", "aud": "b"}
+
+	m := a.Merge(b)
+	if len(m) != 3 || m["iss"] != "a" || m["sub"] != "b" || m["aud"] != "b" {
+		t.Errorf("got %v", m)
+	}
+	if a["sub"] != "a" || len(a) != 2 {
+		t.Errorf("receiver was modified: %v", a)
+	}
+
+	var n jwt.Claims
+	if m := n.Merge(nil); m == nil || len(m) != 0 {
+		t.Errorf("got %v want empty Claims", m)
+	}
+
+	n.MergeInPlace(b)
+	if len(n) != 2 || n["sub"] != "b" {
+		t.Errorf("got %v want %v", n, b)
+	}
+
+	a.MergeInPlace(nil)
+	a.MergeInPlace(b)
+	if len(a) != 3 || a["sub"] != "b" {
+		t.Errorf("got %v", a)
+	}
+}
+
+func TestDeepCopy(t *testing.T) {
+	c := jwt.Claims{
+		"aud":    []string{"a", "b"},
+		"scopes": []interface{}{"read", map[string]interface{}{"x": "y"}},
+		"nested": map[string]interface{}{"k": "v"},
+		"iss":    "example.com",
+	}
+
+	cp := c.DeepCopy()
+	cp["aud"].([]string)[0] = "z"
+	cp["scopes"].([]interface{})[1].(map[string]interface{})["x"] = "z"
+	cp["nested"].(map[string]interface{})["k"] = "z"
+	cp.SetIssuer("other.com")
+
+	if c["aud"].([]string)[0] != "a" ||
+		c["scopes"].([]interface{})[1].(map[string]interface{})["x"] != "y" ||
+		c["nested"].(map[string]interface{})["k"] != "v" ||
+		c["iss"] != "example.com" {
+		t.Errorf("original was modified: %v", c)
+	}
+
+	var n jwt.Claims
+	if n.DeepCopy() != nil {
+		t.Error("got non-nil want nil")
+	}
+}
+
+func TestToAndFromStruct(t *testing.T) {
+	type myClaims struct {
+		UserID string   `json:"sub"`
+		Roles  []string `json:"roles"`
+		Exp    int64    `json:"exp"`
+	}
+
+	c := jws.Claims{}
+	c.SetSubject("1234")
+	c.SetExpiration(time.Unix(1500000000, 0))
+	c.Set("roles", []string{"admin", "user"})
+
+	tok := jws.NewJWT(c, crypto.SigningMethodHS256)
+	b, err := tok.Serialize(hmacKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tok2, err := jws.ParseJWT(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var mc myClaims
+	if err := tok2.Claims().ToStruct(&mc); err != nil {
+		t.Fatal(err)
+	}
+	if mc.UserID != "1234" || mc.Exp != 1500000000 ||
+		len(mc.Roles) != 2 || mc.Roles[1] != "user" {
+		t.Errorf("got %+v", mc)
+	}
+
+	var c2 jwt.Claims
+	if err := c2.FromStruct(mc); err != nil {
+		t.Fatal(err)
+	}
+	if sub, ok := c2.Subject(); !ok || sub != "1234" {
+		t.Errorf("got %q want %q", sub, "1234")
+	}
+	if exp, ok := c2.Expiration(); !ok || exp.Unix() != 1500000000 {
+		t.Errorf("got %v want %v", exp.Unix(), 1500000000)
+	}
+
+	if err := c2.FromStruct("not an object"); err != jwt.ErrNotJSONObject {
+		t.Errorf("got %v want %v", err, jwt.ErrNotJSONObject)
+	}
+	var nilClaims *myClaims
+	if err := c2.FromStruct(nilClaims); err != jwt.ErrNotJSONObject {
+		t.Errorf("got %v want %v", err, jwt.ErrNotJSONObject)
+	}
+	if sub, ok := c2.Subject(); !ok || sub != "1234" {
+		t.Errorf("FromStruct modified the claims: %v", c2)
+	}
+}
+
+func TestEach(t *testing.T) {
+	c := jwt.Claims{"sub": 1, "aud": 2, "iss": 3, "exp": 4}
+
+	var keys []string
+	err := c.Each(func(key string, val interface{}) error {
+		if c[key] != val {
+			t.Errorf("%s: got %v want %v", key, val, c[key])
+		}
+		keys = append(keys, key)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.Join(keys, ","), "aud,exp,iss,sub"; got != want {
+		t.Errorf("got %q want %q", got, want)
+	}
+
+	stop := errors.New("stop")
+	var n int
+	err = c.Each(func(key string, _ interface{}) error {
+		n++
+		if key == "exp" {
+			return stop
+		}
+		return nil
+	})
+	if err != stop || n != 2 {
+		t.Errorf("got (%v, %d) want (%v, 2)", err, n, stop)
+	}
+}
+
+func TestKeysAndSortedPairs(t *testing.T) {
+	c := jwt.Claims{"sub": 1, "aud": 2, "iss": 3}
+
+	if got, want := strings.Join(c.Keys(), ","), "aud,iss,sub"; got != want {
+		t.Errorf("got %q want %q", got, want)
+	}
+
+	want := []jwt.Pair{{"aud", 2}, {"iss", 3}, {"sub", 1}}
+	got := c.SortedPairs()
+	if len(got) != len(want) {
+		t.Fatalf("got %v want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("#%d: got %v want %v", i, got[i], want[i])
+		}
+	}
+
+	if k := (jwt.Claims{}).Keys(); len(k) != 0 {
+		t.Errorf("got %v want []", k)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	old := jwt.Claims{
+		"sub":    "1234",
+		"scopes": []interface{}{"read", "write"},
+		"admin":  true,
+	}
+	cur := jwt.Claims{
+		"sub":    "1234",
+		"scopes": []interface{}{"read"},
+		"tenant": "acme",
+	}
+
+	added, removed, changed := old.Diff(cur)
+	if len(added) != 1 || added["tenant"] != "acme" {
+		t.Errorf("added: got %v", added)
+	}
+	if len(removed) != 1 || removed["admin"] != true {
+		t.Errorf("removed: got %v", removed)
+	}
+	if s, ok := changed["scopes"].([]interface{}); len(changed) != 1 || !ok || len(s) != 1 {
+		t.Errorf("changed: got %v", changed)
+	}
+
+	added, removed, changed = old.Diff(old)
+	if len(added)+len(removed)+len(changed) != 0 {
+		t.Errorf("got %v, %v, %v want empty", added, removed, changed)
+	}
+
+	// Numbers are compared by value, as they would be after parsing.
+	typed := jwt.Claims{"n": int64(5), "m": 2, "ids": []int{1, 2}}
+	parsed := jwt.Claims{"n": float64(5), "m": float64(2), "ids": []interface{}{1.0, 2.0}}
+	added, removed, changed = typed.Diff(parsed)
+	if len(added)+len(removed)+len(changed) != 0 {
+		t.Errorf("got %v, %v, %v want empty", added, removed, changed)
+	}
+	parsed["n"] = 5.5
+	if _, _, changed = typed.Diff(parsed); len(changed) != 1 || changed["n"] != 5.5 {
+		t.Errorf("changed: got %v", changed)
+	}
+}
+
+func TestVerifyClaims(t *testing.T) {
+	c := jwt.Claims{
+		"iss": "example.com",
+		"sub": "1234",
+		"aud": []interface{}{"a.example.com", "b.example.com"},
+	}
+	var empty jwt.Claims
+
+	tests := [...]struct {
+		desc string
+		got  bool
+		want bool
+	}{
+		{"aud match", c.VerifyAudience("b.example.com", true), true},
+		{"aud mismatch", c.VerifyAudience("c.example.com", false), false},
+		{"aud absent, required", empty.VerifyAudience("a.example.com", true), false},
+		{"aud absent, optional", empty.VerifyAudience("a.example.com", false), true},
+		{"aud single", jwt.Claims{"aud": "a"}.VerifyAudience("a", true), true},
+		{"aud wrong type", jwt.Claims{"aud": 42}.VerifyAudience("42", false), false},
+
+		{"iss match", c.VerifyIssuer("example.com", true), true},
+		{"iss mismatch", c.VerifyIssuer("evil.com", false), false},
+		{"iss absent, required", empty.VerifyIssuer("example.com", true), false},
+		{"iss absent, optional", empty.VerifyIssuer("example.com", false), true},
+
+		{"sub match", c.VerifySubject("1234", true), true},
+		{"sub mismatch", c.VerifySubject("5678", true), false},
+		{"sub absent, required", empty.VerifySubject("1234", true), false},
+		{"sub absent, optional", empty.VerifySubject("1234", false), true},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: got %t want %t", tt.desc, tt.got, tt.want)
+		}
+	}
+}
+
+func TestGenerateJTI(t *testing.T) {
+	re := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	c := jwt.Claims{}
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		if err := c.GenerateJTI(); err != nil {
+			t.Fatal(err)
+		}
+		jti, ok := c.JWTID()
+		if !ok || !re.MatchString(jti) {
+			t.Fatalf("invalid jti: %q", jti)
+		}
+		if seen[jti] {
+			t.Fatalf("duplicate jti: %q", jti)
+		}
+		seen[jti] = true
+	}
+}
+
+func TestIsExpiredAndTimeUntilExpiry(t *testing.T) {
+	var c jwt.Claims
+	if c.IsExpired() {
+		t.Error("no exp: got true want false")
+	}
+	if _, ok := c.TimeUntilExpiry(); ok {
+		t.Error("no exp: got true want false")
+	}
+
+	c = jwt.Claims{}
+	c.SetExpiration(time.Now().Add(-time.Minute))
+	if !c.IsExpired() {
+		t.Error("past exp: got false want true")
+	}
+	if d, ok := c.TimeUntilExpiry(); !ok || d >= 0 {
+		t.Errorf("past exp: got (%v, %t) want negative duration", d, ok)
+	}
+
+	c.SetExpiration(time.Now().Add(time.Hour))
+	if c.IsExpired() {
+		t.Error("future exp: got true want false")
+	}
+	if d, ok := c.TimeUntilExpiry(); !ok || d <= 59*time.Minute || d > time.Hour {
+		t.Errorf("future exp: got (%v, %t) want ~1h", d, ok)
+	}
+}
+
+func TestSubsetAndOmit(t *testing.T) {
+	c := jwt.Claims{"iss": "a", "sub": "b", "internal": "c"}
+
+	s := c.Subset("iss", "sub", "missing")
+	if len(s) != 2 || s["iss"] != "a" || s["sub"] != "b" {
+		t.Errorf("got %v", s)
+	}
+
+	o := c.Omit("internal", "missing")
+	if len(o) != 2 || o["iss"] != "a" || o["sub"] != "b" {
+		t.Errorf("got %v", o)
+	}
+
+	if len(c) != 3 {
+		t.Errorf("receiver was modified: %v", c)
+	}
+
+	var n jwt.Claims
+	if s := n.Subset("iss"); s == nil || len(s) != 0 {
+		t.Errorf("got %v want empty Claims", s)
+	}
+	if o := n.Omit("iss"); o == nil || len(o) != 0 {
+		t.Errorf("got %v want empty Claims", o)
+	}
+}
+
+func TestHasAllAndHasAny(t *testing.T) {
+	c := jwt.Claims{"iss": "a", "sub": "b"}
+
+	if !c.HasAll("iss", "sub") {
+		t.Error("HasAll(iss, sub): got false want true")
+	}
+	if c.HasAll("iss", "aud") {
+		t.Error("HasAll(iss, aud): got true want false")
+	}
+	if !c.HasAny("aud", "sub") {
+		t.Error("HasAny(aud, sub): got false want true")
+	}
+	if c.HasAny("aud", "exp") {
+		t.Error("HasAny(aud, exp): got true want false")
+	}
+	if !c.HasAll() || c.HasAny() {
+		t.Error("no keys: want HasAll true and HasAny false")
+	}
+}
+
+func TestFilterByPrefix(t *testing.T) {
+	c := jwt.Claims{
+		"https://myapp.com/roles":  []string{"admin"},
+		"https://myapp.com/tenant": "acme",
+		"https://other.com/roles":  []string{"user"},
+		"sub":                      "1234",
+	}
+
+	f := c.FilterByPrefix("https://myapp.com/")
+	if len(f) != 2 || !f.HasAll("https://myapp.com/roles", "https://myapp.com/tenant") {
+		t.Errorf("got %v", f)
+	}
+	if f := c.FilterByPrefix("nope"); len(f) != 0 {
+		t.Errorf("got %v want empty Claims", f)
+	}
+	if f := c.FilterByPrefix(""); len(f) != len(c) {
+		t.Errorf("got %v want %v", f, c)
+	}
+}
+
+func TestExpiresInAndValidAfter(t *testing.T) {
+	c := jwt.Claims{}
+	before := time.Now().Unix()
+	c.ExpiresIn(15 * time.Minute)
+	c.ValidAfter(-time.Minute)
+	after := time.Now().Unix()
+
+	exp, ok := c.Expiration()
+	if !ok || exp.Unix() < before+15*60 || exp.Unix() > after+15*60 {
+		t.Errorf("exp: got %v", exp)
+	}
+	nbf, ok := c.NotBefore()
+	if !ok || nbf.Unix() < before-60 || nbf.Unix() > after-60 {
+		t.Errorf("nbf: got %v", nbf)
+	}
+	if err := c.Validate(time.Now(), 0, 0); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSetIssuedAtNow(t *testing.T) {
+	c := jwt.Claims{}
+	before := time.Now().Unix()
+	c.SetIssuedAtNow()
+	after := time.Now().Unix()
+
+	iat, ok := c.IssuedAt()
+	if !ok || iat.Unix() < before || iat.Unix() > after {
+		t.Errorf("iat: got %v", iat)
+	}
+}
@@ -1,6 +1,9 @@
 package jwt
 
-import "errors"
+import (
+	"errors"
+	"strconv"
+)
 
 var (
 	// ErrTokenIsExpired is return when time.Now().Unix() is after
@@ -17,6 +20,9 @@ var (
 	// ErrInvalidSUBClaim means the "sub" claim is invalid.
 	ErrInvalidSUBClaim = errors.New("claim \"sub\" is invalid")
 
+	// ErrInvalidEXPClaim means the "exp" claim is invalid.
+	ErrInvalidEXPClaim = errors.New("claim \"exp\" is invalid")
+
 	// ErrInvalidIATClaim means the "iat" claim is invalid.
 	ErrInvalidIATClaim = errors.New("claim \"iat\" is invalid")
 
@@ -25,4 +31,50 @@ var (
 
 	// ErrInvalidAUDClaim means the "aud" claim is invalid.
 	ErrInvalidAUDClaim = errors.New("claim \"aud\" is invalid")
+
+	// ErrNotJSONObject is returned by Claims.FromStruct if its argument
+	// doesn't encode to a JSON object.
+	ErrNotJSONObject = errors.New("claims must encode to a JSON object")
 )
+
+// ErrMissingClaim is returned when a claim listed in
+// Validator.RequiredClaims is not present in the JWT.
+type ErrMissingClaim struct {
+	Claim string // Name of the missing claim.
+}
+
+// Error implements the error interface.
+func (e ErrMissingClaim) Error() string {
+	return "claim " + strconv.Quote(e.Claim) + " is required"
+}
+
+// ErrInvalidClaimValue is returned when the "iss", "sub", or "aud" claim
+// doesn't match the Validator's expected value. For "aud", Expected and
+// Got are comma-separated lists.
+//
+// errors.Is reports whether it matches the corresponding ErrInvalid*Claim
+// error, e.g. ErrInvalidISSClaim.
+type ErrInvalidClaimValue struct {
+	Claim    string // Name of the invalid claim.
+	Expected string // Expected value of the claim.
+	Got      string // Actual value of the claim, empty if absent.
+}
+
+// Error implements the error interface.
+func (e ErrInvalidClaimValue) Error() string {
+	return "claim " + strconv.Quote(e.Claim) + " is invalid: expected " +
+		strconv.Quote(e.Expected) + ", got " + strconv.Quote(e.Got)
+}
+
+// Is reports whether target is the ErrInvalid*Claim error for e's claim.
+func (e ErrInvalidClaimValue) Is(target error) bool {
+	switch e.Claim {
+	case "iss":
+		return target == ErrInvalidISSClaim
+	case "sub":
+		return target == ErrInvalidSUBClaim
+	case "aud":
+		return target == ErrInvalidAUDClaim
+	}
+	return false
+}
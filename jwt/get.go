@@ -0,0 +1,110 @@
+package jwt
+
+import "strings"
+
+// GetString retrieves the claim corresponding with key from c if it's
+// a string.
+func GetString(c Claims, key string) (string, bool) {
+	v, ok := c.Get(key).(string)
+	return v, ok
+}
+
+// GetInt64 retrieves the claim corresponding with key from c if it's
+// an integer. Since numeric values parsed from JSON are stored as
+// float64, float64 values are truncated into an int64.
+func GetInt64(c Claims, key string) (int64, bool) {
+	switch t := c.Get(key).(type) {
+	case int:
+		return int64(t), true
+	case int32:
+		return int64(t), true
+	case int64:
+		return t, true
+	case uint:
+		return int64(t), true
+	case uint32:
+		return int64(t), true
+	case uint64:
+		return int64(t), true
+	case float64:
+		return int64(t), true
+	default:
+		return 0, false
+	}
+}
+
+// GetFloat64 retrieves the claim corresponding with key from c if it's
+// numeric.
+func GetFloat64(c Claims, key string) (float64, bool) {
+	switch t := c.Get(key).(type) {
+	case float64:
+		return t, true
+	case float32:
+		return float64(t), true
+	case int:
+		return float64(t), true
+	case int32:
+		return float64(t), true
+	case int64:
+		return float64(t), true
+	case uint:
+		return float64(t), true
+	case uint32:
+		return float64(t), true
+	case uint64:
+		return float64(t), true
+	default:
+		return 0, false
+	}
+}
+
+// GetBool retrieves the claim corresponding with key from c if it's a
+// bool.
+func GetBool(c Claims, key string) (bool, bool) {
+	v, ok := c.Get(key).(bool)
+	return v, ok
+}
+
+// GetStringSlice retrieves the claim corresponding with key from c if
+// it's a []string or a []interface{} containing only strings, the
+// latter being how JSON arrays are parsed.
+func GetStringSlice(c Claims, key string) ([]string, bool) {
+	switch t := c.Get(key).(type) {
+	case []string:
+		return t, true
+	case []interface{}:
+		s := make([]string, len(t))
+		for i := range t {
+			str, ok := t[i].(string)
+			if !ok {
+				return nil, false
+			}
+			s[i] = str
+		}
+		return s, true
+	default:
+		return nil, false
+	}
+}
+
+// GetNested retrieves a value from nested claims using a dot-separated
+// path. For example, "address.city" retrieves the "city" member of the
+// "address" claim. It returns nil if any segment of the path is missing
+// or if an intermediate value isn't a map[string]interface{}.
+func (c Claims) GetNested(path string) interface{} {
+	var v interface{} = map[string]interface{}(c)
+	for _, key := range strings.Split(path, ".") {
+		switch m := v.(type) {
+		case map[string]interface{}:
+			v = m[key]
+		case Claims:
+			v = m[key]
+		default:
+			return nil
+		}
+		if v == nil {
+			return nil
+		}
+	}
+	return v
+}
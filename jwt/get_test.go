@@ -0,0 +1,91 @@
+package jwt_test
+
+import (
+	"testing"
+
+	"github.com/SermoDigital/jose/jwt"
+)
+
+func TestTypedAccessors(t *testing.T) {
+	c := jwt.Claims{
+		"str":    "s",
+		"int":    int64(42),
+		"float":  float64(42.9),
+		"bool":   true,
+		"slice":  []string{"a", "b"},
+		"islice": []interface{}{"a", "b"},
+		"mixed":  []interface{}{"a", 1},
+	}
+
+	if v, ok := jwt.GetString(c, "str"); !ok || v != "s" {
+		t.Errorf("GetString: got (%q, %t)", v, ok)
+	}
+	if _, ok := jwt.GetString(c, "int"); ok {
+		t.Error("GetString(int): got true want false")
+	}
+
+	if v, ok := jwt.GetInt64(c, "int"); !ok || v != 42 {
+		t.Errorf("GetInt64(int): got (%d, %t)", v, ok)
+	}
+	if v, ok := jwt.GetInt64(c, "float"); !ok || v != 42 {
+		t.Errorf("GetInt64(float): got (%d, %t)", v, ok)
+	}
+	if _, ok := jwt.GetInt64(c, "str"); ok {
+		t.Error("GetInt64(str): got true want false")
+	}
+
+	if v, ok := jwt.GetFloat64(c, "float"); !ok || v != 42.9 {
+		t.Errorf("GetFloat64(float): got (%v, %t)", v, ok)
+	}
+	if v, ok := jwt.GetFloat64(c, "int"); !ok || v != 42 {
+		t.Errorf("GetFloat64(int): got (%v, %t)", v, ok)
+	}
+
+	if v, ok := jwt.GetBool(c, "bool"); !ok || !v {
+		t.Errorf("GetBool: got (%t, %t)", v, ok)
+	}
+	if _, ok := jwt.GetBool(c, "missing"); ok {
+		t.Error("GetBool(missing): got true want false")
+	}
+
+	for _, k := range []string{"slice", "islice"} {
+		if v, ok := jwt.GetStringSlice(c, k); !ok || len(v) != 2 || v[1] != "b" {
+			t.Errorf("GetStringSlice(%s): got (%v, %t)", k, v, ok)
+		}
+	}
+	if _, ok := jwt.GetStringSlice(c, "mixed"); ok {
+		t.Error("GetStringSlice(mixed): got true want false")
+	}
+
+	var n jwt.Claims
+	if _, ok := jwt.GetString(n, "str"); ok {
+		t.Error("nil Claims: got true want false")
+	}
+}
+
+func TestGetNested(t *testing.T) {
+	c := jwt.Claims{
+		"sub": "1234",
+		"address": map[string]interface{}{
+			"city": "Denver",
+			"geo":  jwt.Claims{"lat": 39.7},
+		},
+	}
+
+	tests := [...]struct {
+		path string
+		want interface{}
+	}{
+		{"sub", "1234"},
+		{"address.city", "Denver"},
+		{"address.geo.lat", 39.7},
+		{"address.zip", nil},
+		{"sub.city", nil},
+		{"missing.city", nil},
+	}
+	for _, tt := range tests {
+		if got := c.GetNested(tt.path); got != tt.want {
+			t.Errorf("%q: got %v want %v", tt.path, got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package jwt
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/SermoDigital/jose/crypto"
@@ -35,6 +37,60 @@ type JWT interface {
 // not recommended.
 type ValidateFunc func(Claims) error
 
+// ComposeValidators returns a ValidateFunc which calls each of fns in
+// order, returning the first non-nil error. Nil functions are skipped.
+func ComposeValidators(fns ...ValidateFunc) ValidateFunc {
+	return func(c Claims) error {
+		for _, fn := range fns {
+			if fn == nil {
+				continue
+			}
+			if err := fn(c); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// ComposeValidatorsAll returns a ValidateFunc which calls every one of
+// fns, regardless of whether a previous function failed. Any errors are
+// returned as ValidationErrors. Nil functions are skipped.
+func ComposeValidatorsAll(fns ...ValidateFunc) ValidateFunc {
+	return func(c Claims) error {
+		var errs ValidationErrors
+		for _, fn := range fns {
+			if fn == nil {
+				continue
+			}
+			if err := fn(c); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if len(errs) == 0 {
+			return nil
+		}
+		return errs
+	}
+}
+
+// ValidationErrors is a slice of errors returned from the ValidateFunc
+// created by ComposeValidatorsAll.
+type ValidationErrors []error
+
+// Error implements the error interface.
+func (v ValidationErrors) Error() string {
+	switch len(v) {
+	case 0:
+		return ""
+	case 1:
+		return v[0].Error()
+	case 2:
+		return v[0].Error() + " and 1 other error"
+	}
+	return fmt.Sprintf("%s (and %d other errors)", v[0], len(v)-1)
+}
+
 // Validator represents some of the validation options.
 type Validator struct {
 	Expected Claims        // If non-nil, these are required to match.
@@ -42,6 +98,14 @@ type Validator struct {
 	NBF      time.Duration // NBFLeeway
 	Fn       ValidateFunc  // See ValidateFunc for more information.
 
+	// RequiredClaims lists the claims which must be present in the
+	// JWT, regardless of their values.
+	RequiredClaims []string
+
+	// Clock, if non-nil, is used instead of the current time when
+	// validating the "exp" and "nbf" claims.
+	Clock func() time.Time
+
 	_ struct{} // Require explicitly-named struct fields.
 }
 
@@ -49,15 +113,21 @@ type Validator struct {
 // Note: it only validates the registered claims per
 // https://tools.ietf.org/html/rfc7519#section-4.1
 //
-// Custom claims should be validated using v's Fn member.
+// Custom claims should be validated using v's Fn member. The presence
+// of claims can be enforced using v's RequiredClaims member.
 func (v *Validator) Validate(j JWT) error {
+	for _, key := range v.RequiredClaims {
+		if !j.Claims().Has(key) {
+			return ErrMissingClaim{Claim: key}
+		}
+	}
 	if iss, ok := v.Expected.Issuer(); ok &&
 		j.Claims().Get("iss") != iss {
-		return ErrInvalidISSClaim
+		return invalidClaim(j.Claims(), "iss", iss)
 	}
 	if sub, ok := v.Expected.Subject(); ok &&
 		j.Claims().Get("sub") != sub {
-		return ErrInvalidSUBClaim
+		return invalidClaim(j.Claims(), "sub", sub)
 	}
 	if iat, ok := v.Expected.IssuedAt(); ok {
 		if t, ok := j.Claims().GetTime("iat"); !t.Equal(iat) || !ok {
@@ -72,7 +142,11 @@ func (v *Validator) Validate(j JWT) error {
 	if aud, ok := v.Expected.Audience(); ok {
 		aud2, ok := j.Claims().Audience()
 		if !ok || !ValidAudience(aud, aud2) {
-			return ErrInvalidAUDClaim
+			return ErrInvalidClaimValue{
+				Claim:    "aud",
+				Expected: strings.Join(aud, ","),
+				Got:      strings.Join(aud2, ","),
+			}
 		}
 	}
 
@@ -82,6 +156,15 @@ func (v *Validator) Validate(j JWT) error {
 	return nil
 }
 
+// invalidClaim returns an ErrInvalidClaimValue for the claim key of c.
+func invalidClaim(c Claims, key, expected string) error {
+	var got string
+	if v := c.Get(key); v != nil {
+		got = fmt.Sprint(v)
+	}
+	return ErrInvalidClaimValue{Claim: key, Expected: expected, Got: got}
+}
+
 // SetClaim sets the claim with the given val.
 func (v *Validator) SetClaim(claim string, val interface{}) {
 	v.expect()
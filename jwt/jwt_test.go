@@ -0,0 +1,48 @@
+package jwt_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/SermoDigital/jose/jwt"
+)
+
+func TestComposeValidators(t *testing.T) {
+	var calls int
+	errA, errB := errors.New("a"), errors.New("b")
+
+	ok := func(jwt.Claims) error { calls++; return nil }
+	fail := func(err error) jwt.ValidateFunc {
+		return func(jwt.Claims) error { calls++; return err }
+	}
+
+	fn := jwt.ComposeValidators(ok, nil, fail(errA), fail(errB))
+	if err := fn(jwt.Claims{}); err != errA {
+		t.Errorf("got %v want %v", err, errA)
+	}
+	if calls != 2 {
+		t.Errorf("got %d calls want 2", calls)
+	}
+
+	if err := jwt.ComposeValidators(ok, ok)(jwt.Claims{}); err != nil {
+		t.Errorf("got %v want nil", err)
+	}
+
+	calls = 0
+	fn = jwt.ComposeValidatorsAll(ok, fail(errA), nil, fail(errB))
+	err := fn(jwt.Claims{})
+	errs, isVE := err.(jwt.ValidationErrors)
+	if !isVE || len(errs) != 2 || errs[0] != errA || errs[1] != errB {
+		t.Errorf("got %#v want [%v %v]", err, errA, errB)
+	}
+	if calls != 3 {
+		t.Errorf("got %d calls want 3", calls)
+	}
+	if got, want := err.Error(), "a and 1 other error"; got != want {
+		t.Errorf("got %q want %q", got, want)
+	}
+
+	if err := jwt.ComposeValidatorsAll(ok)(jwt.Claims{}); err != nil {
+		t.Errorf("got %v want nil", err)
+	}
+}